@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/shibataka000/go-get-release/pkg"
+	"github.com/spf13/cobra"
+)
+
+// newApplyCommand return cobra command to install packages listed in manifest.
+func newApplyCommand(opts *options) *cobra.Command {
+	var (
+		manifestPath string
+		lockPath     string
+		frozen       bool
+	)
+
+	command := &cobra.Command{
+		Use:   "apply",
+		Short: "Install executable binaries listed in manifest file.",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			app, err := opts.newApplicationService(ctx)
+			if err != nil {
+				return err
+			}
+			if frozen {
+				return applyFrozen(app, lockPath, opts)
+			}
+
+			manifest, err := app.LoadManifest(manifestPath)
+			if err != nil {
+				return err
+			}
+
+			names := []string{}
+			queries := []pkg.Query{}
+			platforms := []pkg.Platform{}
+			for _, p := range manifest.Packages {
+				query, err := p.ParseQuery()
+				if err != nil {
+					return err
+				}
+				names = append(names, p.Query)
+				queries = append(queries, query)
+				platforms = append(platforms, p.Platform(opts.platform()))
+			}
+			found, searchErr := opts.searchPackages(ctx, app, names, queries, platforms)
+			pkgs, selectErr := selectExecBinaries(app, found, nil, false)
+			searchErr = errors.Join(searchErr, selectErr)
+			if searchErr != nil && len(pkgs) == 0 {
+				return searchErr
+			}
+
+			for _, pkg := range pkgs {
+				printPrompt(pkg)
+			}
+			if !opts.confirm("Are you sure to install executable binaries from above GitHub release assets?") {
+				return searchErr
+			}
+
+			// lock file is written only if all packages in manifest are installed.
+			installations, installErr := opts.installPackages(app, pkgs)
+			if err := errors.Join(searchErr, installErr); err != nil {
+				return err
+			}
+			return app.WriteLock(lockPath, installations)
+		},
+	}
+
+	command.Flags().StringVarP(&manifestPath, "file", "f", "go-get-release.yaml", "manifest file which lists packages to be installed")
+	command.Flags().StringVar(&lockPath, "lock-file", "go-get-release.lock", "lock file which records installed packages")
+	command.Flags().BoolVar(&frozen, "frozen", false, "install packages recorded in lock file without searching GitHub")
+
+	return command
+}
+
+// applyFrozen install packages recorded in lock file.
+func applyFrozen(app *pkg.ApplicationService, lockPath string, opts *options) error {
+	lock, err := app.LoadLock(lockPath)
+	if err != nil {
+		return err
+	}
+
+	for _, locked := range lock.Packages {
+		fmt.Printf("%s\n\n", locked.Package().StringToPrompt())
+	}
+	if !opts.confirm("Are you sure to install executable binaries from above GitHub release assets?") {
+		return nil
+	}
+
+	names := []string{}
+	for _, locked := range lock.Packages {
+		names = append(names, fmt.Sprintf("%s %s", locked.Package().RepositoryName(), locked.ExecBinary))
+	}
+	return opts.runInstalls(names, func(i int, progressBar io.Writer) error {
+		_, err := app.InstallFrozen(lock.Packages[i], opts.installDir, progressBar)
+		return err
+	})
+}
@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// newCacheCommand return cobra command to manage local download cache.
+func newCacheCommand(opts *options) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage local download cache of assets.",
+	}
+	command.AddCommand(newCacheListCommand(opts))
+	command.AddCommand(newCachePruneCommand(opts))
+	command.AddCommand(newCacheCleanCommand(opts))
+	return command
+}
+
+// newCacheListCommand return cobra command to list cached assets.
+func newCacheListCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List cached assets.",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			app, err := opts.newApplicationService(ctx)
+			if err != nil {
+				return err
+			}
+			// Corrupted cache entries are reported after listing others.
+			assets, listErr := app.ListCachedAssets()
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "URL\tSIZE\tDOWNLOADED AT\tLAST USED AT")
+			for _, asset := range assets {
+				fmt.Fprintf(w, "%s\t%d\t%s\t%s\n", asset.URL, asset.Size, asset.DownloadedAt.Local().Format(time.RFC3339), asset.LastUsedAt.Local().Format(time.RFC3339))
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			return listErr
+		},
+	}
+}
+
+// newCachePruneCommand return cobra command to remove cached assets which were not used recently.
+func newCachePruneCommand(opts *options) *cobra.Command {
+	var olderThan string
+
+	command := &cobra.Command{
+		Use:   "prune",
+		Short: "Remove cached assets which were not used recently.",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			age, err := parseAge(olderThan)
+			if err != nil {
+				return err
+			}
+			ctx := context.Background()
+			app, err := opts.newApplicationService(ctx)
+			if err != nil {
+				return err
+			}
+			removed, err := app.PruneCache(time.Now().Add(-age))
+			for _, asset := range removed {
+				fmt.Printf("Removed %s\n", asset.URL)
+			}
+			return err
+		},
+	}
+
+	command.Flags().StringVar(&olderThan, "older-than", "30d", "remove assets which were not used for this duration, e.g. \"30d\" or \"12h\"")
+
+	return command
+}
+
+// newCacheCleanCommand return cobra command to remove all cache.
+func newCacheCleanCommand(opts *options) *cobra.Command {
+	return &cobra.Command{
+		Use:   "clean",
+		Short: "Remove all cached assets and indices.",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			app, err := opts.newApplicationService(ctx)
+			if err != nil {
+				return err
+			}
+			return app.CleanCache()
+		},
+	}
+}
+
+// parseAge parse duration such as "12h".
+// Unlike time.ParseDuration, this also accepts days such as "30d".
+func parseAge(age string) (time.Duration, error) {
+	if days, ok := strings.CutSuffix(age, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("%s is invalid duration", age)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	return time.ParseDuration(age)
+}
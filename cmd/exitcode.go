@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/shibataka000/go-get-release/pkg"
+)
+
+// Exit codes returned by go-get-release.
+const (
+	ExitCodeOK                 = 0
+	ExitCodeError              = 1
+	ExitCodeRepositoryNotFound = 3
+	ExitCodeAssetNotFound      = 4
+	ExitCodeExecBinaryNotFound = 5
+	ExitCodeNotCached          = 6
+	ExitCodePlatformMismatch   = 7
+	ExitCodeReleaseNotFound    = 8
+)
+
+// ExitCode return exit code corresponding to error.
+func ExitCode(err error) int {
+	switch {
+	case err == nil:
+		return ExitCodeOK
+	case errors.Is(err, pkg.ErrRepositoryNotFound):
+		return ExitCodeRepositoryNotFound
+	case errors.Is(err, pkg.ErrReleaseNotFound):
+		return ExitCodeReleaseNotFound
+	case errors.Is(err, pkg.ErrAssetNotFound):
+		return ExitCodeAssetNotFound
+	case errors.Is(err, pkg.ErrExecBinaryNotFound):
+		return ExitCodeExecBinaryNotFound
+	case errors.Is(err, pkg.ErrNotCached):
+		return ExitCodeNotCached
+	case errors.Is(err, pkg.ErrPlatformMismatch):
+		return ExitCodePlatformMismatch
+	default:
+		return ExitCodeError
+	}
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/shibataka000/go-get-release/pkg"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		exitCode int
+	}{
+		{
+			name:     "nil",
+			err:      nil,
+			exitCode: ExitCodeOK,
+		},
+		{
+			name:     "other error",
+			err:      errors.New("something went wrong"),
+			exitCode: ExitCodeError,
+		},
+		{
+			name:     "repository not found",
+			err:      pkg.ErrRepositoryNotFound,
+			exitCode: ExitCodeRepositoryNotFound,
+		},
+		{
+			name:     "release not found",
+			err:      pkg.ErrReleaseNotFound,
+			exitCode: ExitCodeReleaseNotFound,
+		},
+		{
+			name:     "asset not found",
+			err:      pkg.ErrAssetNotFound,
+			exitCode: ExitCodeAssetNotFound,
+		},
+		{
+			name:     "executable binary not found",
+			err:      pkg.ErrExecBinaryNotFound,
+			exitCode: ExitCodeExecBinaryNotFound,
+		},
+		{
+			name:     "not cached",
+			err:      pkg.ErrNotCached,
+			exitCode: ExitCodeNotCached,
+		},
+		{
+			name:     "platform mismatch",
+			err:      pkg.ErrPlatformMismatch,
+			exitCode: ExitCodePlatformMismatch,
+		},
+		{
+			name:     "wrapped",
+			err:      fmt.Errorf("shibataka000/go-get-release: %w", pkg.ErrAssetNotFound),
+			exitCode: ExitCodeAssetNotFound,
+		},
+		{
+			name:     "joined",
+			err:      errors.Join(errors.New("something went wrong"), fmt.Errorf("foo: %w", pkg.ErrRepositoryNotFound)),
+			exitCode: ExitCodeRepositoryNotFound,
+		},
+		{
+			name:     "executable binary candidates",
+			err:      &pkg.ExecBinaryCandidatesError{Asset: "tool.zip", Candidates: []pkg.FileName{"bar.exe", "foo.exe"}},
+			exitCode: ExitCodeExecBinaryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.exitCode, ExitCode(tt.err))
+		})
+	}
+}
+
+func TestExitCodeGiteaReleaseNotFound(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{
+			name:  "latest",
+			query: "gitea:shibataka000/hello",
+		},
+		{
+			name:  "constraint",
+			query: "gitea:shibataka000/hello@>=0.1",
+		},
+		{
+			name:  "tag",
+			query: "gitea:shibataka000/hello=v0.1.0",
+		},
+	}
+
+	// Fake Gitea API server which has "shibataka000/hello" repository without any release.
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/repos/shibataka000/hello":
+			_, _ = w.Write([]byte(`{"name":"hello","owner":{"login":"shibataka000"}}`))
+		case "/api/v1/repos/shibataka000/hello/releases":
+			_, _ = w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			t.Setenv("XDG_CACHE_HOME", t.TempDir())
+			ctx := context.Background()
+			repository, err := pkg.NewInfrastructureRepository(ctx, pkg.InfrastructureOptions{GiteaURL: server.URL})
+			assert.NoError(err)
+			app := pkg.NewApplicationService(repository, pkg.NewFactory(), pkg.InstallOptions{})
+
+			query, err := pkg.ParseQuery(tt.query)
+			assert.NoError(err)
+			_, err = app.Search(ctx, query, pkg.NewPlatform("linux", "amd64"))
+			assert.Equal(ExitCodeReleaseNotFound, ExitCode(err))
+		})
+	}
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// newListCommand return cobra command to list packages installed by go-get-release.
+func newListCommand(opts *options) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "list",
+		Short: "List executable binaries installed by go-get-release.",
+		Args:  cobra.NoArgs,
+		RunE: func(_ *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			app, err := opts.newApplicationService(ctx)
+			if err != nil {
+				return err
+			}
+			pkgs, err := app.ListInstalledPackages()
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "REPO\tTAG\tASSET\tPATH\tINSTALLED AT")
+			for _, pkg := range pkgs {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", pkg.RepositoryName(), pkg.Tag, pkg.DownloadURL.FileName(), pkg.Path, pkg.InstalledAt.Local().Format(time.RFC3339))
+			}
+			return w.Flush()
+		},
+	}
+	return command
+}
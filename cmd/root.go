@@ -2,55 +2,303 @@ package cmd
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 
 	"github.com/Songmu/prompter"
 	"github.com/shibataka000/go-get-release/pkg"
 	"github.com/spf13/cobra"
+	"golang.org/x/term"
 )
 
+// options is common options of commands.
+type options struct {
+	token       string
+	githubURL   string
+	gitlabURL   string
+	gitlabToken string
+	giteaURL    string
+	giteaToken  string
+	goos        string
+	goarch      string
+	installDir  string
+	yes         bool
+	indexPaths  []string
+	offline     bool
+	jobs        int
+
+	skipPlatformCheck bool
+	allowUnverified   bool
+}
+
 // NewCommand return cobra command
 func NewCommand() *cobra.Command {
+	opts := &options{}
 	var (
-		token      string
-		goos       string
-		goarch     string
-		installDir string
+		execBinaries    []string
+		allExecBinaries bool
+		extras          bool
+		force           bool
 	)
 
 	command := &cobra.Command{
-		Use:   "go-get-release [<owner>/]<repo>[=<tag>]",
+		Use:   "go-get-release [<source>:][<owner>/]<repo>[=<tag>|@<constraint>] | <url>...",
 		Short: "Install executable binary from GitHub release asset.",
-		Args:  cobra.ExactArgs(1),
+		Args:  cobra.MinimumNArgs(1),
 		RunE: func(_ *cobra.Command, args []string) error {
+			if (len(execBinaries) > 0 || allExecBinaries) && len(args) > 1 {
+				return errors.New("--binary and --all-binaries can't be used with multiple queries")
+			}
+			names := []pkg.FileName{}
+			for _, execBinary := range execBinaries {
+				names = append(names, pkg.NewFileName(execBinary))
+			}
 			ctx := context.Background()
-			repository := pkg.NewInfrastructureRepository(ctx, token)
-			factory := pkg.NewFactory()
-			app := pkg.NewApplicationService(repository, factory)
-			platform := pkg.NewPlatform(goos, goarch)
-			query, err := pkg.ParseQuery(args[0])
+			app, err := opts.newApplicationService(ctx)
 			if err != nil {
 				return err
 			}
-			pkg, err := app.Search(ctx, query, platform)
-			if err != nil {
-				return err
+			queries := []pkg.Query{}
+			platforms := []pkg.Platform{}
+			for _, arg := range args {
+				query, err := pkg.ParseQuery(arg)
+				if err != nil {
+					return err
+				}
+				if len(names) > 0 {
+					query.ExecBinary = names[0]
+				}
+				query.Extras = extras
+				query.OverwriteExtras = force
+				queries = append(queries, query)
+				platforms = append(platforms, opts.platform())
+			}
+			found, searchErr := opts.searchPackages(ctx, app, args, queries, platforms)
+			pkgs, selectErr := selectExecBinaries(app, found, names, allExecBinaries)
+			searchErr = errors.Join(searchErr, selectErr)
+			if len(pkgs) == 0 {
+				return searchErr
+			}
+			for _, p := range pkgs {
+				printPrompt(p)
 			}
-			fmt.Printf("%s\n\n", pkg.StringToPrompt())
-			if !prompter.YN("Are you sure to install executable binary from above GitHub release asset?", true) {
-				return nil
+			if !opts.confirm("Are you sure to install executable binary from above asset?") {
+				return searchErr
 			}
-			fmt.Println()
-			return app.Install(pkg, installDir, os.Stderr)
+			_, installErr := opts.installPackages(app, pkgs)
+			return errors.Join(searchErr, installErr)
 		},
 	}
 
-	command.Flags().StringVar(&token, "token", os.Getenv("GITHUB_TOKEN"), "github token [$GITHUB_TOKEN]")
-	command.Flags().StringVar(&goos, "goos", os.Getenv("GOOS"), "goos [$GOOS]")
-	command.Flags().StringVar(&goarch, "goarch", os.Getenv("GOARCH"), "goarch [$GOARCH]")
-	command.Flags().StringVar(&installDir, "install-dir", filepath.Join(os.Getenv("GOPATH"), "bin"), "directory where executable binary will be installed to")
+	command.Flags().StringArrayVar(&execBinaries, "binary", []string{}, "executable binary name in asset (can be repeated to install multiple executable binaries)")
+	command.Flags().BoolVar(&allExecBinaries, "all-binaries", false, "install all executable binaries in asset")
+	command.MarkFlagsMutuallyExclusive("binary", "all-binaries")
+	command.Flags().BoolVar(&extras, "extras", false, "also install shell completions, man pages and license files bundled in asset")
+	command.Flags().BoolVar(&force, "force", false, "overwrite existing extra files which were not installed by go-get-release")
+	command.PersistentFlags().StringVar(&opts.token, "token", os.Getenv("GITHUB_TOKEN"), "github token [$GITHUB_TOKEN]")
+	command.PersistentFlags().StringVar(&opts.githubURL, "github-url", os.Getenv("GITHUB_API_URL"), "GitHub API URL of GitHub Enterprise Server [$GITHUB_API_URL]")
+	command.PersistentFlags().StringVar(&opts.gitlabURL, "gitlab-url", os.Getenv("GITLAB_URL"), "GitLab URL used by \"gitlab:\" query (default https://gitlab.com) [$GITLAB_URL]")
+	command.PersistentFlags().StringVar(&opts.gitlabToken, "gitlab-token", os.Getenv("GITLAB_TOKEN"), "GitLab token [$GITLAB_TOKEN]")
+	command.PersistentFlags().StringVar(&opts.giteaURL, "gitea-url", os.Getenv("GITEA_URL"), "Gitea or Forgejo URL used by \"gitea:\" query (default https://codeberg.org) [$GITEA_URL]")
+	command.PersistentFlags().StringVar(&opts.giteaToken, "gitea-token", os.Getenv("GITEA_TOKEN"), "Gitea or Forgejo token [$GITEA_TOKEN]")
+	command.PersistentFlags().StringVar(&opts.goos, "goos", os.Getenv("GOOS"), "goos [$GOOS]")
+	command.PersistentFlags().StringVar(&opts.goarch, "goarch", os.Getenv("GOARCH"), "goarch [$GOARCH]")
+	command.PersistentFlags().StringVar(&opts.installDir, "install-dir", filepath.Join(os.Getenv("GOPATH"), "bin"), "directory where executable binary will be installed to")
+	command.PersistentFlags().StringSliceVar(&opts.indexPaths, "index", []string{}, "index files or HTTP(S) URLs which take precedence over built-in index")
+	command.PersistentFlags().IntVarP(&opts.jobs, "jobs", "j", 4, "number of executable binaries searched and installed concurrently")
+	command.PersistentFlags().BoolVar(&opts.skipPlatformCheck, "skip-platform-check", false, "install executable binary even if it is not built for --goos and --goarch, with warning")
+	command.PersistentFlags().BoolVar(&opts.allowUnverified, "allow-unverified", false, "install executable binary without prompt even if its asset can't be verified by checksum file")
+	command.PersistentFlags().BoolVar(&opts.offline, "offline", false, "use only cached metadata and assets without accessing network")
+	command.PersistentFlags().BoolVarP(&opts.yes, "yes", "y", false, "install without prompt")
+	command.PersistentFlags().BoolVar(&opts.yes, "non-interactive", false, "install without prompt (alias of --yes)")
+
+	command.AddCommand(newApplyCommand(opts))
+	command.AddCommand(newListCommand(opts))
+	command.AddCommand(newUpgradeCommand(opts))
+	command.AddCommand(newUninstallCommand(opts))
+	command.AddCommand(newCacheCommand(opts))
 
 	return command
 }
+
+// newApplicationService return new application service instance.
+func (o *options) newApplicationService(ctx context.Context) (*pkg.ApplicationService, error) {
+	repository, err := pkg.NewInfrastructureRepository(ctx, pkg.InfrastructureOptions{
+		Token:       o.token,
+		GitHubURL:   o.githubURL,
+		GitLabURL:   o.gitlabURL,
+		GitLabToken: o.gitlabToken,
+		GiteaURL:    o.giteaURL,
+		GiteaToken:  o.giteaToken,
+		IndexPaths:  o.indexPaths,
+		Offline:     o.offline,
+	})
+	if err != nil {
+		return nil, err
+	}
+	factory := pkg.NewFactory()
+	// Asset which can't be verified is installed without prompt only if user opts in.
+	return pkg.NewApplicationService(repository, factory, pkg.InstallOptions{
+		SkipPlatformCheck: o.skipPlatformCheck,
+		RequireChecksum:   !o.allowUnverified && (o.yes || !isInteractive()),
+		Warnings:          os.Stderr,
+	}), nil
+}
+
+// platform return platform specified by options.
+func (o *options) platform() pkg.Platform {
+	return pkg.NewPlatform(o.goos, o.goarch)
+}
+
+// searchPackages search packages matching queries concurrently.
+// names are used to report which query failed. platforms[i] is platform to install package matching queries[i].
+// Even if some of queries fail, packages matching others are returned with joined errors.
+func (o *options) searchPackages(ctx context.Context, app *pkg.ApplicationService, names []string, queries []pkg.Query, platforms []pkg.Platform) ([]pkg.Package, error) {
+	pkgs := make([]pkg.Package, len(queries))
+	found := make([]bool, len(queries))
+	err := pkg.RunConcurrently(len(queries), o.jobs, func(i int) error {
+		p, err := app.Search(ctx, queries[i], platforms[i])
+		if err != nil {
+			return fmt.Errorf("%s: %w", names[i], err)
+		}
+		pkgs[i] = p
+		found[i] = true
+		return nil
+	})
+	result := []pkg.Package{}
+	for i, p := range pkgs {
+		if found[i] {
+			result = append(result, p)
+		}
+	}
+	return result, err
+}
+
+// selectExecBinaries return packages which install executable binaries selected from asset of each of pkgs.
+// See pkg.ApplicationService.SelectExecBinaries about how executable binaries are selected.
+// Even if some of pkgs fail, packages selected from others are returned with joined errors.
+func selectExecBinaries(app *pkg.ApplicationService, pkgs []pkg.Package, names []pkg.FileName, all bool) ([]pkg.Package, error) {
+	result := []pkg.Package{}
+	errs := []error{}
+	for _, p := range pkgs {
+		selected, err := app.SelectExecBinaries(p, names, all, os.Stderr)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", p.RepositoryName(), err))
+			continue
+		}
+		result = append(result, selected...)
+	}
+	return result, errors.Join(errs...)
+}
+
+// installPackages install packages to install directory concurrently.
+// If executable binary of some package was not found by its guessed name and multiple candidates were detected in asset,
+// user is asked to choose one of them after other packages are installed.
+// Even if some of packages fail, others are installed. Installations of succeeded ones are returned with joined errors.
+func (o *options) installPackages(app *pkg.ApplicationService, pkgs []pkg.Package) ([]pkg.Installation, error) {
+	names := []string{}
+	for _, p := range pkgs {
+		names = append(names, fmt.Sprintf("%s %s", p.RepositoryName(), p.ExecBinary.Name))
+	}
+	installations := make([]pkg.Installation, len(pkgs))
+	errs := make([]error, len(pkgs))
+	_ = o.runInstalls(names, func(i int, progressBar io.Writer) error {
+		installations[i], errs[i] = app.Install(pkgs[i], o.installDir, progressBar)
+		return errs[i]
+	})
+	for i, err := range errs {
+		var candidatesErr *pkg.ExecBinaryCandidatesError
+		if !errors.As(err, &candidatesErr) || o.yes || !isInteractive() {
+			continue
+		}
+		execBinary := chooseExecBinary(pkgs[i].RepositoryName(), candidatesErr.Candidates)
+		installations[i], errs[i] = app.Install(pkgs[i].WithExecBinary(pkg.NewExecBinary(execBinary)), o.installDir, os.Stderr)
+	}
+
+	result := []pkg.Installation{}
+	for i, installation := range installations {
+		if errs[i] != nil {
+			errs[i] = fmt.Errorf("%s: %w", names[i], errs[i])
+			continue
+		}
+		result = append(result, installation)
+	}
+	return result, errors.Join(errs...)
+}
+
+// chooseExecBinary ask user which of candidates to install as executable binary of package named name.
+func chooseExecBinary(name string, candidates []pkg.FileName) pkg.FileName {
+	choices := []string{}
+	for _, candidate := range candidates {
+		choices = append(choices, candidate.String())
+	}
+	choice := prompter.Choose(fmt.Sprintf("Multiple executable binaries were found in asset of %s. Which one to install?", name), choices, choices[0])
+	fmt.Println()
+	return pkg.NewFileName(choice)
+}
+
+// runInstalls call install for each of names concurrently with their progress bars drawn together.
+// Error returned by install is prefixed by name.
+func (o *options) runInstalls(names []string, install func(i int, progressBar io.Writer) error) error {
+	progressBars, stop := newProgressBars(names)
+	defer stop()
+	return pkg.RunConcurrently(len(names), o.jobs, func(i int) error {
+		if err := install(i, progressBars[i]); err != nil {
+			return fmt.Errorf("%s: %w", names[i], err)
+		}
+		return nil
+	})
+}
+
+// newProgressBars return progress bar writers for each of names and function to stop drawing them.
+// If stderr can't draw multiple progress bars, e.g. it is not terminal, only single progress bar is drawn as usual
+// and progress of multiple downloads is not shown.
+func newProgressBars(names []string) ([]io.Writer, func()) {
+	progressBars := make([]io.Writer, len(names))
+	pool, err := pkg.NewProgressBarPool(os.Stderr, names)
+	if err != nil {
+		for i := range progressBars {
+			progressBars[i] = io.Discard
+			if len(names) == 1 {
+				progressBars[i] = os.Stderr
+			}
+		}
+		return progressBars, func() {}
+	}
+	for i := range progressBars {
+		progressBars[i] = pool.ProgressBar(i)
+	}
+	return progressBars, func() { _ = pool.Stop() }
+}
+
+// printPrompt print package to be installed before confirmation.
+// If asset of package can't be verified because it has no checksum file, warning is printed too.
+func printPrompt(p pkg.Package) {
+	prompt := p.StringToPrompt()
+	if p.Checksum.IsEmpty() {
+		prompt = fmt.Sprintf("%s\nWarning:\tchecksum file was not found, so asset can't be verified", prompt)
+	}
+	fmt.Printf("%s\n\n", prompt)
+}
+
+// confirm ask user whether to continue and return true if user answer yes.
+// If --yes is specified or stdin is not terminal, this return true without prompt.
+func (o *options) confirm(message string) bool {
+	if o.yes || !isInteractive() {
+		return true
+	}
+	if !prompter.YN(message, true) {
+		return false
+	}
+	fmt.Println()
+	return true
+}
+
+// isInteractive return true if stdin is terminal.
+// If not, prompt is skipped because nobody can answer it.
+func isInteractive() bool {
+	return term.IsTerminal(int(os.Stdin.Fd()))
+}
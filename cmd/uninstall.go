@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// newUninstallCommand return cobra command to uninstall packages installed by go-get-release.
+func newUninstallCommand(opts *options) *cobra.Command {
+	var force bool
+
+	command := &cobra.Command{
+		Use:   "uninstall <name|owner/repo>...",
+		Short: "Uninstall executable binaries installed by go-get-release.",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(_ *cobra.Command, args []string) error {
+			ctx := context.Background()
+			app, err := opts.newApplicationService(ctx)
+			if err != nil {
+				return err
+			}
+			pkgs, err := app.FindInstalledPackages(args)
+			if err != nil {
+				return err
+			}
+
+			for _, pkg := range pkgs {
+				fmt.Printf("Repo:\t%s\nTag:\t%s\nPath:\t%s\n\n", pkg.RepositoryName(), pkg.Tag, pkg.Path)
+			}
+			if !opts.confirm("Are you sure to uninstall above executable binaries?") {
+				return nil
+			}
+
+			for _, pkg := range pkgs {
+				if err := app.Uninstall(pkg, force); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	}
+
+	command.Flags().BoolVar(&force, "force", false, "remove executable binaries even if they were modified after installed")
+
+	return command
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/shibataka000/go-get-release/pkg"
+	"github.com/spf13/cobra"
+)
+
+// newUpgradeCommand return cobra command to upgrade packages installed by go-get-release.
+func newUpgradeCommand(opts *options) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "upgrade [<name>...]",
+		Short: "Upgrade executable binaries installed by go-get-release to latest releases.",
+		RunE: func(_ *cobra.Command, args []string) error {
+			ctx := context.Background()
+			app, err := opts.newApplicationService(ctx)
+			if err != nil {
+				return err
+			}
+			upgrades, searchErr := app.SearchUpgrades(ctx, args, opts.platform(), opts.jobs)
+			if searchErr != nil && len(upgrades) == 0 {
+				return searchErr
+			}
+
+			outdated := []pkg.Upgrade{}
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "REPO\tBINARY\tCURRENT\t\tAVAILABLE")
+			for _, upgrade := range upgrades {
+				fmt.Fprintf(w, "%s\t%s\t%s\t→\t%s\n", upgrade.Installed.RepositoryName(), upgrade.Installed.ExecBinary, upgrade.Installed.Tag, upgrade.Available.Release.Tag)
+				if upgrade.IsOutdated() {
+					outdated = append(outdated, upgrade)
+				}
+			}
+			if err := w.Flush(); err != nil {
+				return err
+			}
+			fmt.Println()
+
+			if len(outdated) == 0 {
+				fmt.Println("All executable binaries are up to date.")
+				return searchErr
+			}
+			if !opts.confirm(fmt.Sprintf("Are you sure to upgrade %d executable binaries?", len(outdated))) {
+				return searchErr
+			}
+
+			names := []string{}
+			for _, upgrade := range outdated {
+				names = append(names, fmt.Sprintf("%s %s", upgrade.Installed.RepositoryName(), upgrade.Installed.ExecBinary))
+			}
+			installErr := opts.runInstalls(names, func(i int, progressBar io.Writer) error {
+				_, err := app.Install(outdated[i].Available, outdated[i].InstallDir(), progressBar)
+				return err
+			})
+			return errors.Join(searchErr, installErr)
+		},
+	}
+	return command
+}
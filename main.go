@@ -2,12 +2,14 @@ package main
 
 import (
 	"log"
+	"os"
 
 	"github.com/shibataka000/go-get-release/cmd"
 )
 
 func main() {
 	if err := cmd.NewCommand().Execute(); err != nil {
-		log.Fatal(err)
+		log.Print(err)
+		os.Exit(cmd.ExitCode(err))
 	}
 }
@@ -2,28 +2,59 @@ package pkg
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"regexp"
+	"strings"
+	"sync"
+	"time"
 )
 
 // ApplicationService.
 type ApplicationService struct {
 	repository *InfrastructureRepository
 	factory    *Factory
+	options    InstallOptions
+	inventory  sync.Mutex
+}
+
+// InstallOptions is options to install packages.
+// If SkipPlatformCheck is true, executable binary which is not built for platform is installed, and mismatch is written to Warnings.
+// If RequireChecksum is true, package whose asset can't be verified by checksum file is not installed.
+// Otherwise it is installed with warning.
+// Warnings receives warnings found while installing packages. If it is nil, they are discarded.
+type InstallOptions struct {
+	SkipPlatformCheck bool
+	RequireChecksum   bool
+	Warnings          io.Writer
 }
 
 // Query to search package.
 type Query struct {
+	Source     Source
 	Repository Repository
 	Tag        string
+	Constraint Constraint
+	ExecBinary FileName
+	URL        URL
+	Extras     bool
+	// OverwriteExtras is true if extra files may overwrite existing files which were not installed by this application.
+	OverwriteExtras bool
 }
 
 // NewApplicationService return new application service instance.
-func NewApplicationService(repository *InfrastructureRepository, factory *Factory) *ApplicationService {
+func NewApplicationService(repository *InfrastructureRepository, factory *Factory, options InstallOptions) *ApplicationService {
+	if options.Warnings == nil {
+		options.Warnings = io.Discard
+	}
 	return &ApplicationService{
 		repository: repository,
 		factory:    factory,
+		options:    options,
 	}
 }
 
@@ -35,38 +66,55 @@ func NewQuery(repo Repository, tag string) Query {
 	}
 }
 
+// NewQueryFromURL return new query instance to install asset downloaded from URL directly.
+func NewQueryFromURL(url URL) Query {
+	return Query{
+		Source: SourceURL,
+		URL:    url,
+	}
+}
+
 // Search package.
+// If query has URL, asset is downloaded from it directly without searching any release host.
 func (a *ApplicationService) Search(ctx context.Context, query Query, platform Platform) (Package, error) {
-	var err error
+	if query.HasURL() {
+		return a.searchURL(query, platform), nil
+	}
+
+	source, err := a.repository.ReleaseSource(query.Source)
+	if err != nil {
+		return Package{}, err
+	}
 
-	var ghRepo GitHubRepository
+	var repo Repository
 	if query.HasOwner() {
-		ghRepo, err = a.repository.FindGitHubRepository(ctx, query.Repository.Owner, query.Repository.Name)
+		repo, err = source.FindRepository(ctx, query.Repository.Owner, query.Repository.Name)
 	} else {
-		ghRepo, err = a.repository.SearchGitHubRepository(ctx, query.Repository.Name)
+		repo, err = source.SearchRepository(ctx, query.Repository.Name)
 	}
 	if err != nil {
 		return Package{}, err
 	}
-	repo := a.factory.NewRepository(ghRepo)
 
-	var ghRelease GitHubRelease
+	var release Release
 	if query.HasTag() {
-		ghRelease, err = a.repository.FindGitHubReleaseByTag(ctx, ghRepo, query.Tag)
+		release, err = source.FindReleaseByTag(ctx, repo, query.Tag)
+	} else if query.HasConstraint() {
+		release, err = findReleaseByConstraint(ctx, source, repo, query.Constraint)
 	} else {
-		ghRelease, err = a.repository.LatestGitHubRelease(ctx, ghRepo)
+		release, err = source.LatestRelease(ctx, repo)
 	}
 	if err != nil {
 		return Package{}, err
 	}
-	release := a.factory.NewRelease(ghRelease)
 
-	index, err := a.repository.LoadBuiltInIndex()
+	index, err := a.loadIndex(query.Source)
 	if err != nil {
 		return Package{}, err
 	}
 
 	var asset Asset
+	var checksum Checksum
 	if index.HasAsset(repo, platform) {
 		assetInIndex, err := index.FindAsset(repo, platform)
 		if err != nil {
@@ -76,52 +124,611 @@ func (a *ApplicationService) Search(ctx context.Context, query Query, platform P
 		if err != nil {
 			return Package{}, err
 		}
+		checksum, err = a.factory.NewChecksumFromIndex(assetInIndex, release)
+		if err != nil {
+			return Package{}, err
+		}
 	} else {
-		ghAssets, err := a.repository.ListGitHubAssets(ctx, ghRepo, ghRelease)
+		assets, err := source.ListAssets(ctx, repo, release)
 		if err != nil {
 			return Package{}, err
 		}
-		asset, err = a.factory.NewAssetFromGitHub(ghAssets, platform)
+		asset, err = a.factory.NewAssetFromSource(assets, platform)
 		if err != nil {
 			return Package{}, err
 		}
+		checksum = a.factory.NewChecksumFromSource(assets, asset)
 	}
 
 	var execBinary ExecBinary
-	if index.HasExecBinary(repo) {
+	guessed := false
+	if query.HasExecBinary() {
+		execBinary, err = a.execBinaryByName(index, repo, query.ExecBinary, release, platform)
+		if err != nil {
+			return Package{}, err
+		}
+	} else if index.HasExecBinary(repo) {
 		execBinaryInIndex, err := index.FindExecBinary(repo)
 		if err != nil {
 			return Package{}, err
 		}
-		execBinary = a.factory.NewExecBinaryFromIndex(execBinaryInIndex, platform)
+		execBinary, err = a.factory.NewExecBinaryFromIndex(execBinaryInIndex, release, platform)
+		if err != nil {
+			return Package{}, err
+		}
+	} else {
+		execBinary = a.factory.NewExecBinaryFromRepository(repo, platform)
+		guessed = true
+	}
+
+	pkg := New(repo, release, asset, execBinary, checksum)
+	pkg.ExecBinaryGuessed = guessed
+	pkg.Source = query.Source
+	pkg.Platform = platform
+	pkg.Constraint = query.Constraint
+	// Extra files declared in index are installed only if extra files are requested.
+	if query.Extras {
+		pkg.Extras = NewExtras(true, index.FindExtras(repo))
+	}
+	pkg.Extras.Overwrite = query.OverwriteExtras
+	return pkg, nil
+}
+
+// searchURL return package whose asset is downloaded from URL in query.
+// Executable binary name is guessed by asset file name unless query has it.
+func (a *ApplicationService) searchURL(query Query, platform Platform) Package {
+	var execBinary ExecBinary
+	if query.HasExecBinary() {
+		execBinary = a.factory.NewExecBinaryWithPlatform(query.ExecBinary, platform)
 	} else {
-		execBinary = a.factory.NewExecBinaryFromGitHub(ghRepo, platform)
+		execBinary = a.factory.NewExecBinaryFromURL(query.URL, platform)
+	}
+	pkg := New(Repository{}, Release{}, NewAsset(query.URL), execBinary, Checksum{})
+	pkg.ExecBinaryGuessed = !query.HasExecBinary()
+	pkg.Source = SourceURL
+	pkg.Platform = platform
+	pkg.Extras = NewExtras(query.Extras, nil)
+	pkg.Extras.Overwrite = query.OverwriteExtras
+	return pkg
+}
+
+// SelectExecBinaries return packages which install executable binaries selected from asset of pkg.
+// If all is true, every executable binary in asset is selected, so asset is downloaded to local download cache to list them.
+// Otherwise executable binaries named by names are selected.
+// If names are empty and pkg has executable binary defined first in index, all executable binaries defined in index are selected.
+// If none of them applies, only pkg is returned.
+func (a *ApplicationService) SelectExecBinaries(pkg Package, names []FileName, all bool, progressBar io.Writer) ([]Package, error) {
+	execBinaries := []ExecBinary{}
+	switch {
+	case all:
+		found, err := a.listExecBinaries(pkg, progressBar)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range found {
+			execBinaries = append(execBinaries, NewExecBinary(name))
+		}
+	case len(names) > 0:
+		index, err := a.loadIndex(pkg.Source)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			execBinary, err := a.execBinaryByName(index, pkg.Repository, name, pkg.Release, pkg.Platform)
+			if err != nil {
+				return nil, err
+			}
+			execBinaries = append(execBinaries, execBinary)
+		}
+	case pkg.Source != SourceURL:
+		index, err := a.loadIndex(pkg.Source)
+		if err != nil {
+			return nil, err
+		}
+		if !index.HasExecBinary(pkg.Repository) {
+			return []Package{pkg}, nil
+		}
+		execBinariesInIndex, err := index.FindExecBinaries(pkg.Repository)
+		if err != nil {
+			return nil, err
+		}
+		for _, execBinaryInIndex := range execBinariesInIndex {
+			execBinary, err := a.factory.NewExecBinaryFromIndex(execBinaryInIndex, pkg.Release, pkg.Platform)
+			if err != nil {
+				return nil, err
+			}
+			execBinaries = append(execBinaries, execBinary)
+		}
+		if pkg.ExecBinary != execBinaries[0] {
+			return []Package{pkg}, nil
+		}
+	default:
+		return []Package{pkg}, nil
+	}
+
+	pkgs := []Package{}
+	for _, execBinary := range execBinaries {
+		pkgs = append(pkgs, pkg.WithExecBinary(execBinary))
+	}
+	return pkgs, nil
+}
+
+// execBinaryByName return executable binary named name.
+// If index defines executable binary with same name, it is used so that its glob or regular expression is kept, e.g. when package in inventory is upgraded.
+func (a *ApplicationService) execBinaryByName(index Index, repo Repository, name FileName, release Release, platform Platform) (ExecBinary, error) {
+	execBinary := a.factory.NewExecBinaryWithPlatform(name, platform)
+	execBinariesInIndex, err := index.FindExecBinaries(repo)
+	if err != nil {
+		return execBinary, nil
+	}
+	for _, execBinaryInIndex := range execBinariesInIndex {
+		if execBinaryInIndex.BaseName == name || a.factory.NewExecBinaryWithPlatform(execBinaryInIndex.BaseName, platform) == execBinary {
+			return a.factory.NewExecBinaryFromIndex(execBinaryInIndex, release, platform)
+		}
 	}
+	return execBinary, nil
+}
+
+// listExecBinaries download asset of pkg to local download cache and list executable binaries in it.
+// If asset is not archive, executable binary of pkg is returned.
+func (a *ApplicationService) listExecBinaries(pkg Package, progressBar io.Writer) ([]FileName, error) {
+	assetName := pkg.Asset.DownloadURL.FileName()
+	if !assetName.IsArchived() {
+		return []FileName{pkg.ExecBinary.Name}, nil
+	}
+	assetDigest, err := a.assetDigest(pkg)
+	if err != nil {
+		return nil, err
+	}
+	asset, err := a.repository.DownloadToCache(pkg.Asset.DownloadURL, assetDigest, progressBar)
+	if err != nil {
+		return nil, err
+	}
+	defer asset.Close()
+	info, err := asset.Stat()
+	if err != nil {
+		return nil, err
+	}
+	names, err := ListExecBinaries(asset, info.Size(), assetName)
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%w in %s", ErrExecBinaryNotFound, assetName)
+	}
+	return names, nil
+}
 
-	return New(repo, release, asset, execBinary), nil
+// findReleaseByConstraint return release which has highest semver satisfying constraint.
+func findReleaseByConstraint(ctx context.Context, source ReleaseSource, repo Repository, constraint Constraint) (Release, error) {
+	releases, err := source.ListReleases(ctx, repo)
+	if err != nil {
+		return Release{}, err
+	}
+	return FindReleaseByConstraint(releases, constraint)
+}
+
+// loadIndex return index to find package from source.
+// Index describes only repositories in GitHub, so empty index is returned for other sources
+// not to apply it to repository which has same owner and name in another host.
+func (a *ApplicationService) loadIndex(source Source) (Index, error) {
+	if !source.IsGitHub() {
+		return NewIndex(nil), nil
+	}
+	return a.repository.LoadIndex()
 }
 
 // Install package.
-func (a *ApplicationService) Install(pkg Package, dir string, progressBar io.Writer) error {
-	asset, err := a.repository.Download(pkg.Asset.DownloadURL, progressBar)
+// If package has checksum file, digest of downloaded asset is verified before installing.
+// Install can be called concurrently.
+func (a *ApplicationService) Install(pkg Package, dir string, progressBar io.Writer) (Installation, error) {
+	return a.install(pkg, dir, progressBar, "")
+}
+
+// InstallFrozen install package recorded in lock file without searching GitHub.
+// If digest of executable binary differs from one in lock file, this return error without installing.
+func (a *ApplicationService) InstallFrozen(locked PackageInLock, dir string, progressBar io.Writer) (Installation, error) {
+	return a.install(locked.Package(), dir, progressBar, locked.SHA256)
+}
+
+// assetDigest return digest of asset of pkg found in its checksum file.
+// If package has no checksum file, empty digest is returned.
+func (a *ApplicationService) assetDigest(pkg Package) (Digest, error) {
+	if pkg.Checksum.IsEmpty() {
+		return "", nil
+	}
+	checksum, err := a.repository.Download(pkg.Checksum.DownloadURL, io.Discard)
+	if err != nil {
+		return "", err
+	}
+	return ChecksumFile(checksum).Digest(pkg.Asset.DownloadURL.FileName())
+}
+
+// install package.
+// If digest is not empty, digest of executable binary is verified before installing.
+// Asset is downloaded to local download cache and executable binary is extracted from it on the fly,
+// so neither of them is buffered in memory.
+func (a *ApplicationService) install(pkg Package, dir string, progressBar io.Writer, digest Digest) (Installation, error) {
+	assetName := pkg.Asset.DownloadURL.FileName()
+	assetDigest, err := a.assetDigest(pkg)
+	if err != nil {
+		return Installation{}, err
+	}
+	// Executable binary installed from lock file is verified by its digest in lock file instead.
+	if assetDigest == "" && digest == "" {
+		err := fmt.Errorf("%w: checksum file for %s was not found", ErrUnverified, assetName)
+		if a.options.RequireChecksum {
+			return Installation{}, err
+		}
+		fmt.Fprintf(a.options.Warnings, "warning: %v\n", err)
+	}
+	asset, err := a.repository.DownloadToCache(pkg.Asset.DownloadURL, assetDigest, progressBar)
+	if err != nil {
+		return Installation{}, err
+	}
+	defer asset.Close()
+	assetInfo, err := asset.Stat()
+	if err != nil {
+		return Installation{}, err
+	}
+
+	if assetDigest != "" {
+		if err := VerifyReader(io.NewSectionReader(asset, 0, assetInfo.Size()), assetName, assetDigest); err != nil {
+			// Asset which doesn't match checksum must not be reused from cache.
+			asset.Close()
+			return Installation{}, errors.Join(err, a.repository.EvictCachedAsset(pkg.Asset.DownloadURL, assetDigest))
+		}
+	}
+
+	execBinary, err := a.repository.CreateTempFile(dir)
 	if err != nil {
+		return Installation{}, err
+	}
+	defer a.repository.RemoveTempFile(execBinary)
+	err = CopyExecBinary(execBinary, asset, assetInfo.Size(), assetName, pkg.ExecBinary)
+	if errors.Is(err, ErrExecBinaryNotFound) && pkg.ExecBinaryGuessed {
+		pkg, err = a.detectExecBinary(pkg, asset, assetInfo.Size())
+		if err != nil {
+			return Installation{}, err
+		}
+		err = CopyExecBinary(execBinary, asset, assetInfo.Size(), assetName, pkg.ExecBinary)
+	}
+	if err != nil {
+		return Installation{}, err
+	}
+	execBinaryInfo, err := execBinary.Stat()
+	if err != nil {
+		return Installation{}, err
+	}
+	if err := VerifyExecutablePlatform(io.NewSectionReader(execBinary, 0, execBinaryInfo.Size()), pkg.ExecBinary.Name, pkg.Platform); err != nil {
+		if !a.options.SkipPlatformCheck {
+			return Installation{}, err
+		}
+		fmt.Fprintf(a.options.Warnings, "warning: %v\n", err)
+	}
+	sha256, err := SHA256Reader(io.NewSectionReader(execBinary, 0, execBinaryInfo.Size()))
+	if err != nil {
+		return Installation{}, err
+	}
+	if digest != "" {
+		if err := VerifyReader(io.NewSectionReader(execBinary, 0, execBinaryInfo.Size()), pkg.ExecBinary.Name, digest); err != nil {
+			return Installation{}, err
+		}
+	}
+
+	// Extra files are extracted before taking lock of inventory and moved into place after executable binary is installed.
+	extras, err := a.extractExtras(pkg, asset, assetInfo.Size())
+	defer func() {
+		for _, extra := range extras {
+			a.repository.RemoveTempFile(extra.file)
+		}
+	}()
+	if err != nil {
+		return Installation{}, err
+	}
+
+	path, err := filepath.Abs(filepath.Join(dir, pkg.ExecBinary.Name.String()))
+	if err != nil {
+		return Installation{}, err
+	}
+
+	a.inventory.Lock()
+	defer a.inventory.Unlock()
+	inventory, err := a.repository.LoadInventory()
+	if err != nil {
+		return Installation{}, err
+	}
+	// Nothing is installed if any extra file would overwrite file which is not owned by this application.
+	for _, extra := range extras {
+		if err := a.checkExtraOverwritable(inventory, extra.InstalledExtra, pkg.Extras.Overwrite); err != nil {
+			return Installation{}, err
+		}
+	}
+	if err := a.repository.RenameTempFile(execBinary, path, 0755); err != nil {
+		return Installation{}, err
+	}
+	installation := NewInstallation(pkg, path, sha256, time.Now())
+	// Executable binary is already installed, so extra files which fail to be installed are reported after recording others.
+	extrasErr := []error{}
+	for _, extra := range extras {
+		if err := a.repository.RenameTempFile(extra.file, extra.Path, 0644); err != nil {
+			extrasErr = append(extrasErr, err)
+			continue
+		}
+		installation.Extras = append(installation.Extras, extra.InstalledExtra)
+	}
+	if err := a.repository.WriteInventory(inventory.Add(installation)); err != nil {
+		return Installation{}, err
+	}
+	return installation, errors.Join(extrasErr...)
+}
+
+// detectExecBinary return copy of pkg whose executable binary is detected by contents of asset.
+// If multiple executable binaries are detected, ExecBinaryCandidatesError is returned.
+func (a *ApplicationService) detectExecBinary(pkg Package, asset io.ReaderAt, size int64) (Package, error) {
+	assetName := pkg.Asset.DownloadURL.FileName()
+	candidates, err := DetectExecBinaries(asset, size, assetName)
+	if err != nil {
+		return Package{}, err
+	}
+	switch len(candidates) {
+	case 0:
+		return Package{}, fmt.Errorf("%w in %s: %s", ErrExecBinaryNotFound, assetName, pkg.ExecBinary)
+	case 1:
+		return pkg.WithExecBinary(NewExecBinary(candidates[0])), nil
+	default:
+		return Package{}, &ExecBinaryCandidatesError{
+			Asset:      assetName,
+			Candidates: candidates,
+		}
+	}
+}
+
+// extractedExtra is extra file extracted to temporary file next to path where it is installed.
+type extractedExtra struct {
+	InstalledExtra
+	file *os.File
+}
+
+// extractExtras extract extra files bundled with executable binary in asset, such as shell completions,
+// man pages and license files, to temporary files. Returned ones must be removed by RemoveTempFile even if error is returned.
+// If package doesn't select any extra or asset is not archive, nothing is extracted.
+func (a *ApplicationService) extractExtras(pkg Package, asset io.ReaderAt, size int64) ([]extractedExtra, error) {
+	assetName := pkg.Asset.DownloadURL.FileName()
+	if pkg.Extras.IsEmpty() || !assetName.IsArchived() {
+		return nil, nil
+	}
+	extras := []extractedExtra{}
+	err := WalkArchive(asset, size, assetName, func(path string, _ fs.FileMode, r io.Reader) error {
+		extra, ok := pkg.Extras.Select(path)
+		if !ok {
+			return nil
+		}
+		dst, err := a.repository.ExtraPath(extra, pkg.ExecBinary)
+		if err != nil {
+			return err
+		}
+		// If multiple files in archive are installed to same path, first one is used.
+		for _, extracted := range extras {
+			if extracted.Path == dst {
+				return nil
+			}
+		}
+		file, err := a.repository.CreateTempFileFor(dst)
+		if err != nil {
+			return err
+		}
+		extras = append(extras, extractedExtra{file: file})
+		sha256, err := SHA256Reader(io.TeeReader(r, file))
+		if err != nil {
+			return err
+		}
+		extras[len(extras)-1].InstalledExtra = NewInstalledExtra(dst, sha256)
+		return nil
+	})
+	return extras, err
+}
+
+// checkExtraOverwritable return error if file already exists at path of extra and it must not be overwritten.
+// File can be overwritten if it was installed by this application, it has same contents as extra, or overwrite is true.
+func (a *ApplicationService) checkExtraOverwritable(inventory Inventory, extra InstalledExtra, overwrite bool) error {
+	if overwrite || inventory.HasExtra(extra.Path) {
+		return nil
+	}
+	file, err := a.repository.ReadFile(extra.Path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return nil
+	case err != nil:
 		return err
+	case file.SHA256() != extra.SHA256:
+		return fmt.Errorf("%s already exists and was not installed by go-get-release", extra.Path)
+	default:
+		return nil
+	}
+}
+
+// SearchUpgrades search latest releases of installed packages which match any of names.
+// If names are empty, all installed packages are searched.
+// At most jobs packages are searched concurrently. Even if some of them fail, upgrades of others are returned with joined errors.
+func (a *ApplicationService) SearchUpgrades(ctx context.Context, names []string, platform Platform, jobs int) ([]Upgrade, error) {
+	inventory, err := a.repository.LoadInventory()
+	if err != nil {
+		return nil, err
+	}
+	installed, err := inventory.Find(names)
+	if err != nil {
+		return nil, err
 	}
-	execBinary, err := AssetFile(asset).ExecBinary(pkg.ExecBinary.Name)
+	available := make([]Package, len(installed))
+	found := make([]bool, len(installed))
+	err = RunConcurrently(len(installed), jobs, func(i int) error {
+		p := installed[i]
+		pkg, err := a.Search(ctx, p.Query(), p.Platform(platform))
+		if err != nil {
+			return fmt.Errorf("%s: %w", p.RepositoryName(), err)
+		}
+		available[i] = pkg
+		found[i] = true
+		return nil
+	})
+	upgrades := []Upgrade{}
+	for i, p := range installed {
+		if found[i] {
+			upgrades = append(upgrades, NewUpgrade(p, available[i]))
+		}
+	}
+	return upgrades, err
+}
+
+// FindInstalledPackages return installed packages which match any of names.
+func (a *ApplicationService) FindInstalledPackages(names []string) ([]PackageInInventory, error) {
+	inventory, err := a.repository.LoadInventory()
+	if err != nil {
+		return nil, err
+	}
+	return inventory.Find(names)
+}
+
+// Uninstall remove executable binary installed by this application, extra files installed with it and its record in inventory.
+// If executable binary or any of extra files was modified after installed, this return error without removing anything unless force is true.
+func (a *ApplicationService) Uninstall(pkg PackageInInventory, force bool) error {
+	a.inventory.Lock()
+	defer a.inventory.Unlock()
+	inventory, err := a.repository.LoadInventory()
 	if err != nil {
 		return err
 	}
-	return a.repository.WriteFile(File(execBinary), dir, 0755)
+	inventory = inventory.Remove(pkg.Path)
+
+	// Files are checked before removing any of them. Ones which were already removed are skipped and only their records are removed.
+	paths := []string{}
+	exists, err := a.checkUnmodified(pkg.Path, pkg.SHA256, force)
+	if err != nil {
+		return err
+	}
+	if exists {
+		paths = append(paths, pkg.Path)
+	}
+	for _, extra := range pkg.Extras {
+		// extra files shared with other installed packages, e.g. ones installed from same asset, are kept.
+		if inventory.HasExtra(extra.Path) {
+			continue
+		}
+		exists, err := a.checkUnmodified(extra.Path, extra.SHA256, force)
+		if err != nil {
+			return err
+		}
+		if exists {
+			paths = append(paths, extra.Path)
+		}
+	}
+	for _, path := range paths {
+		if err := a.repository.RemoveFile(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return a.repository.WriteInventory(inventory)
+}
+
+// checkUnmodified return true if file installed by this application exists at path.
+// If its digest differs from sha256, i.e. it was modified after installed, this return error unless force is true.
+func (a *ApplicationService) checkUnmodified(path string, sha256 Digest, force bool) (bool, error) {
+	file, err := a.repository.ReadFile(path)
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return false, nil
+	case err != nil:
+		return false, err
+	case !force && file.SHA256() != sha256:
+		return false, fmt.Errorf("%s was modified after installed by go-get-release", path)
+	default:
+		return true, nil
+	}
+}
+
+// ListInstalledPackages return packages installed by this application.
+func (a *ApplicationService) ListInstalledPackages() ([]PackageInInventory, error) {
+	inventory, err := a.repository.LoadInventory()
+	if err != nil {
+		return nil, err
+	}
+	return inventory.List(), nil
+}
+
+// ListCachedAssets return assets in local download cache.
+// Assets whose metadata is corrupted are skipped and reported by returned error.
+func (a *ApplicationService) ListCachedAssets() ([]AssetInCache, error) {
+	return a.repository.ListCachedAssets()
+}
+
+// PruneCache remove assets which were not used since specified time from local download cache and return removed ones.
+func (a *ApplicationService) PruneCache(unusedSince time.Time) ([]AssetInCache, error) {
+	// Corrupted cache entries are reported after removing others.
+	assets, listErr := a.repository.ListCachedAssets()
+	removed := []AssetInCache{}
+	for _, asset := range assets {
+		if !asset.IsUnusedSince(unusedSince) {
+			continue
+		}
+		if err := a.repository.RemoveCachedAsset(asset); err != nil {
+			return removed, err
+		}
+		removed = append(removed, asset)
+	}
+	return removed, listErr
+}
+
+// CleanCache remove all cache of this application.
+func (a *ApplicationService) CleanCache() error {
+	return a.repository.CleanCache()
+}
+
+// LoadLock load lock file which records resolved packages.
+func (a *ApplicationService) LoadLock(path string) (Lock, error) {
+	return a.repository.LoadLock(path)
+}
+
+// WriteLock write lock file which records installed packages.
+func (a *ApplicationService) WriteLock(path string, installations []Installation) error {
+	return a.repository.WriteLock(path, NewLockFromInstallations(installations))
+}
+
+// LoadManifest load manifest which lists packages to be installed.
+func (a *ApplicationService) LoadManifest(path string) (Manifest, error) {
+	return a.repository.LoadManifest(path)
 }
 
 // ParseQuery parse query string and return query instance.
+// Query string is "[<source>:][<owner>/]<repo>[=<tag>]" or "[<source>:][<owner>/]<repo>@<constraint>".
+// Source is "github", "gitlab", "codeberg" or "gitea" and GitHub is used if it is omitted.
+// Owner can contain "/" to specify GitLab subgroup.
+// Query string can also be HTTP(S) URL of asset.
 func ParseQuery(query string) (Query, error) {
-	re := regexp.MustCompile(`(([^/=]+)/)?([^/=]+)(=([^/=]+))?`)
+	if url := NewURL(query); url.IsHTTP() {
+		return NewQueryFromURL(url), nil
+	}
+	re := regexp.MustCompile(`^(([a-z]+):)?(([^:=@]+)/)?([^/:=@]+)(=([^/=@]+)|@(.+))?$`)
 	submatch := re.FindStringSubmatch(query)
-	if submatch == nil || len(submatch) != 6 {
+	if submatch == nil || len(submatch) != 9 {
 		return Query{}, fmt.Errorf("%s is invalid query", query)
 	}
-	return NewQuery(NewRepository(submatch[2], submatch[3]), submatch[5]), nil
+	source, err := NewSource(submatch[2])
+	if err != nil {
+		return Query{}, err
+	}
+	if source.IsGitHub() && strings.Contains(submatch[4], "/") {
+		return Query{}, fmt.Errorf("%s is invalid query", query)
+	}
+	q := NewQuery(NewRepository(submatch[4], submatch[5]), submatch[7])
+	q.Source = source
+	q.Constraint = NewConstraint(submatch[8])
+	if q.HasConstraint() {
+		if err := q.Constraint.Validate(); err != nil {
+			return Query{}, err
+		}
+	}
+	return q, nil
 }
 
 // HasOwner return true if query has repository owner.
@@ -133,3 +740,18 @@ func (q Query) HasOwner() bool {
 func (q Query) HasTag() bool {
 	return q.Tag != ""
 }
+
+// HasConstraint return true if query has semver constraint.
+func (q Query) HasConstraint() bool {
+	return !q.Constraint.IsEmpty()
+}
+
+// HasExecBinary return true if query has executable binary name.
+func (q Query) HasExecBinary() bool {
+	return q.ExecBinary != ""
+}
+
+// HasURL return true if query has asset URL.
+func (q Query) HasURL() bool {
+	return q.URL != ""
+}
@@ -1,20 +1,29 @@
 package pkg
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
 func NewApplicationServiceForTest(ctx context.Context, t *testing.T) *ApplicationService {
 	t.Helper()
-	repository := NewInfrastructureRepository(ctx, os.Getenv("GITHUB_TOKEN"))
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	repository, err := NewInfrastructureRepository(ctx, InfrastructureOptions{Token: os.Getenv("GITHUB_TOKEN")})
+	require.NoError(t, err)
 	factory := NewFactory()
-	return NewApplicationService(repository, factory)
+	return NewApplicationService(repository, factory, InstallOptions{})
 }
 
 func TestApplicationServiceInstall(t *testing.T) {
@@ -68,6 +77,8 @@ func TestApplicationServiceInstall(t *testing.T) {
 			dir, err := os.MkdirTemp("", "*")
 			assert.NoError(err)
 			t.Setenv("PATH", dir)
+			t.Setenv("XDG_DATA_HOME", t.TempDir())
+			t.Setenv("XDG_CACHE_HOME", t.TempDir())
 			defer os.RemoveAll(dir)
 
 			ctx := context.Background()
@@ -78,7 +89,7 @@ func TestApplicationServiceInstall(t *testing.T) {
 			pkg, err := app.Search(ctx, query, platform)
 			assert.NoError(err)
 
-			err = app.Install(pkg, dir, io.Discard)
+			_, err = app.Install(pkg, dir, io.Discard)
 			assert.NoError(err)
 
 			cmd = exec.Command(tt.verifyCommand[0], tt.verifyCommand[1:]...)
@@ -88,6 +99,832 @@ func TestApplicationServiceInstall(t *testing.T) {
 	}
 }
 
+func TestApplicationServiceInstallFrozen(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		sha256   Digest
+		err      error
+		contents []byte
+	}{
+		{
+			name:     "test.gz",
+			path:     "/test.gz",
+			sha256:   "8cd07f3a5ff98f2a78cfc366c13fb123eb8d29c1ca37c79df190425d5b9e424d",
+			contents: []byte("helloworld\n"),
+		},
+		{
+			name:   "digest mismatch",
+			path:   "/test.gz",
+			sha256: "0000000000000000000000000000000000000000000000000000000000000000",
+			err:    fmt.Errorf("checksum of test mismatched: expected 0000000000000000000000000000000000000000000000000000000000000000 but got 8cd07f3a5ff98f2a78cfc366c13fb123eb8d29c1ca37c79df190425d5b9e424d"),
+		},
+	}
+
+	server := httptest.NewServer(http.FileServer(http.Dir("./testdata")))
+	defer server.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			dir := t.TempDir()
+			t.Setenv("XDG_DATA_HOME", t.TempDir())
+			t.Setenv("XDG_CACHE_HOME", t.TempDir())
+			ctx := context.Background()
+			app := NewApplicationServiceForTest(ctx, t)
+			locked := NewPackageInLock("shibataka000", "go-get-release-test", "v0.0.1", NewURL(server.URL+tt.path), "test", tt.sha256)
+			installation, err := app.InstallFrozen(locked, dir, io.Discard)
+			if tt.err != nil {
+				assert.EqualError(err, tt.err.Error())
+				assert.NoFileExists(filepath.Join(dir, "test"))
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(filepath.Join(dir, "test"), installation.Path)
+			assert.Equal(tt.sha256, installation.SHA256)
+			contents, err := os.ReadFile(installation.Path)
+			assert.NoError(err)
+			assert.Equal(tt.contents, contents)
+			installed, err := app.ListInstalledPackages()
+			assert.NoError(err)
+			assert.Len(installed, 1)
+			assert.Equal(installation.Path, installed[0].Path)
+			assert.Equal(locked.DownloadURL, installed[0].DownloadURL)
+		})
+	}
+}
+
+func TestApplicationServiceInstallFrozenRoundTrip(t *testing.T) {
+	assert := require.New(t)
+	dir := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	server := httptest.NewServer(http.FileServer(http.Dir("./testdata")))
+	defer server.Close()
+
+	ctx := context.Background()
+	app := NewApplicationServiceForTest(ctx, t)
+	query, err := ParseQuery(server.URL + "/extras.tar.gz")
+	assert.NoError(err)
+	query.ExecBinary = "tool"
+	query.Extras = true
+	pkg, err := app.Search(ctx, query, NewPlatform("linux", "amd64"))
+	assert.NoError(err)
+	installation, err := app.Install(pkg, dir, io.Discard)
+	assert.NoError(err)
+	lockPath := filepath.Join(t.TempDir(), "go-get-release.lock")
+	assert.NoError(app.WriteLock(lockPath, []Installation{installation}))
+	assert.NoError(app.Uninstall(NewPackageInInventoryFromInstallation(installation), false))
+
+	// package installed from lock file is recorded as same source, platform and extra files as it was resolved.
+	lock, err := app.LoadLock(lockPath)
+	assert.NoError(err)
+	assert.Len(lock.Packages, 1)
+	frozen, err := app.InstallFrozen(lock.Packages[0], dir, io.Discard)
+	assert.NoError(err)
+	assert.Equal(pkg.Source, frozen.Package.Source)
+	assert.Equal(pkg.Platform, frozen.Package.Platform)
+	assert.Equal(pkg.Extras, frozen.Package.Extras)
+	assert.Equal(installation.Extras, frozen.Extras)
+	installed, err := app.ListInstalledPackages()
+	assert.NoError(err)
+	assert.Len(installed, 1)
+	assert.Equal(SourceURL, installed[0].Source)
+	assert.Equal(pkg.Platform, installed[0].Platform(Platform{}))
+	assert.Equal(query, installed[0].Query())
+}
+
+func TestApplicationServiceInstallChecksum(t *testing.T) {
+	tests := []struct {
+		name     string
+		checksum string
+		err      string
+	}{
+		{
+			name:     "match",
+			checksum: "e1100a46cfa4aa807fda3f13109785ee88f4598865f5b1fb3a7cae4238677323  test.gz\n",
+		},
+		{
+			name:     "mismatch",
+			checksum: "0000000000000000000000000000000000000000000000000000000000000000  test.gz\n",
+			err:      "checksum of test.gz mismatched",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			server := NewTestDataServerForTest(t, func(w http.ResponseWriter, r *http.Request, fileServer http.Handler) {
+				if r.URL.Path == "/test.gz.sha256" {
+					_, _ = w.Write([]byte(tt.checksum))
+					return
+				}
+				fileServer.ServeHTTP(w, r)
+			})
+			dir := t.TempDir()
+			ctx := context.Background()
+			app := NewApplicationServiceForTest(ctx, t)
+			url := NewURL(server.URL + "/test.gz")
+			pkg := New(NewRepository("shibataka000", "test"), NewRelease("v0.0.1"), NewAsset(url), NewExecBinary("test"), NewChecksum(NewURL(server.URL+"/test.gz.sha256")))
+
+			_, err := app.Install(pkg, dir, io.Discard)
+			assets, listErr := app.ListCachedAssets()
+			assert.NoError(listErr)
+			urls := []URL{}
+			for _, asset := range assets {
+				urls = append(urls, asset.URL)
+			}
+			if tt.err != "" {
+				assert.ErrorContains(err, tt.err)
+				assert.NoFileExists(filepath.Join(dir, "test"))
+				// asset which doesn't match checksum is evicted from cache.
+				assert.NotContains(urls, url)
+				return
+			}
+			assert.NoError(err)
+			assert.FileExists(filepath.Join(dir, "test"))
+			assert.Contains(urls, url)
+		})
+	}
+}
+
+func TestApplicationServiceInstallFromURL(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		execBinary FileName
+		installed  string
+	}{
+		{
+			name:      "test.gz",
+			path:      "/test.gz",
+			installed: "test",
+		},
+		{
+			name:       "test.tar.gz with binary name",
+			path:       "/test.tar.gz",
+			execBinary: "test",
+			installed:  "test",
+		},
+	}
+
+	server := httptest.NewServer(http.FileServer(http.Dir("./testdata")))
+	defer server.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			dir := t.TempDir()
+			t.Setenv("XDG_DATA_HOME", t.TempDir())
+			t.Setenv("XDG_CACHE_HOME", t.TempDir())
+			ctx := context.Background()
+			app := NewApplicationServiceForTest(ctx, t)
+			query, err := ParseQuery(server.URL + tt.path)
+			assert.NoError(err)
+			query.ExecBinary = tt.execBinary
+			pkg, err := app.Search(ctx, query, NewPlatform("linux", "amd64"))
+			assert.NoError(err)
+			assert.Equal(SourceURL, pkg.Source)
+			installation, err := app.Install(pkg, dir, io.Discard)
+			assert.NoError(err)
+			assert.Equal(filepath.Join(dir, tt.installed), installation.Path)
+			contents, err := os.ReadFile(installation.Path)
+			assert.NoError(err)
+			assert.Equal([]byte("helloworld\n"), contents)
+
+			// installed package can be searched again by its query to upgrade.
+			installed, err := app.ListInstalledPackages()
+			assert.NoError(err)
+			assert.Len(installed, 1)
+			assert.Equal(query.URL, installed[0].Query().URL)
+		})
+	}
+}
+
+func TestApplicationServiceInstallDetectedExecBinary(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		installed  string
+		candidates []FileName
+	}{
+		{
+			name:      "single executable binary",
+			path:      "/detect.tar.gz",
+			installed: "mytool",
+		},
+		{
+			name:       "multiple executable binaries",
+			path:       "/detect.zip",
+			candidates: []FileName{"bar.exe", "foo.exe"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			server := NewTestDataServerForTest(t, nil)
+			dir := t.TempDir()
+			ctx := context.Background()
+			app := NewApplicationServiceForTest(ctx, t)
+			query, err := ParseQuery(server.URL + tt.path)
+			assert.NoError(err)
+			pkg, err := app.Search(ctx, query, NewPlatform("linux", "amd64"))
+			assert.NoError(err)
+			assert.True(pkg.ExecBinaryGuessed)
+
+			installation, err := app.Install(pkg, dir, io.Discard)
+			if tt.candidates != nil {
+				var candidatesErr *ExecBinaryCandidatesError
+				assert.ErrorAs(err, &candidatesErr)
+				assert.ErrorIs(err, ErrExecBinaryNotFound)
+				assert.Equal(tt.candidates, candidatesErr.Candidates)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(filepath.Join(dir, tt.installed), installation.Path)
+			assert.Equal(NewFileName(tt.installed), installation.Package.ExecBinary.Name)
+		})
+	}
+}
+
+func TestApplicationServiceInstallPlatformMismatch(t *testing.T) {
+	tests := []struct {
+		name              string
+		skipPlatformCheck bool
+		err               error
+		warning           string
+	}{
+		{
+			name: "mismatch",
+			err:  ErrPlatformMismatch,
+		},
+		{
+			name:              "skip platform check",
+			skipPlatformCheck: true,
+			warning:           "warning: asset can't be verified: checksum file for elf-arm64 was not found\nwarning: executable binary is not built for platform: elf is built for arm64 but amd64 is requested\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			dir := t.TempDir()
+			t.Setenv("XDG_DATA_HOME", t.TempDir())
+			t.Setenv("XDG_CACHE_HOME", t.TempDir())
+			server := httptest.NewServer(http.FileServer(http.Dir("./testdata")))
+			defer server.Close()
+
+			ctx := context.Background()
+			repository, err := NewInfrastructureRepository(ctx, InfrastructureOptions{})
+			assert.NoError(err)
+			warnings := new(bytes.Buffer)
+			app := NewApplicationService(repository, NewFactory(), InstallOptions{SkipPlatformCheck: tt.skipPlatformCheck, Warnings: warnings})
+			query, err := ParseQuery(server.URL + "/executable/elf-arm64")
+			assert.NoError(err)
+			pkg, err := app.Search(ctx, query, NewPlatform("linux", "amd64"))
+			assert.NoError(err)
+			installation, err := app.Install(pkg, dir, io.Discard)
+			installed, listErr := app.ListInstalledPackages()
+			assert.NoError(listErr)
+			if tt.err != nil {
+				assert.ErrorIs(err, tt.err)
+				assert.NoFileExists(filepath.Join(dir, "elf"))
+				assert.Len(installed, 0)
+				return
+			}
+			assert.NoError(err)
+			assert.FileExists(installation.Path)
+			assert.Len(installed, 1)
+			assert.Equal(tt.warning, warnings.String())
+		})
+	}
+}
+
+func TestApplicationServiceInstallUnverified(t *testing.T) {
+	tests := []struct {
+		name            string
+		requireChecksum bool
+		err             error
+		warning         string
+	}{
+		{
+			name:    "warn",
+			warning: "warning: asset can't be verified: checksum file for elf was not found\n",
+		},
+		{
+			name:            "require checksum",
+			requireChecksum: true,
+			err:             ErrUnverified,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			dir := t.TempDir()
+			t.Setenv("XDG_DATA_HOME", t.TempDir())
+			t.Setenv("XDG_CACHE_HOME", t.TempDir())
+			server := httptest.NewServer(http.FileServer(http.Dir("./testdata")))
+			defer server.Close()
+
+			ctx := context.Background()
+			repository, err := NewInfrastructureRepository(ctx, InfrastructureOptions{})
+			assert.NoError(err)
+			warnings := new(bytes.Buffer)
+			app := NewApplicationService(repository, NewFactory(), InstallOptions{RequireChecksum: tt.requireChecksum, Warnings: warnings})
+			query, err := ParseQuery(server.URL + "/executable/elf")
+			assert.NoError(err)
+			pkg, err := app.Search(ctx, query, NewPlatform("linux", "amd64"))
+			assert.NoError(err)
+			installation, err := app.Install(pkg, dir, io.Discard)
+			if tt.err != nil {
+				assert.ErrorIs(err, tt.err)
+				assert.NoFileExists(filepath.Join(dir, "elf"))
+				return
+			}
+			assert.NoError(err)
+			assert.FileExists(installation.Path)
+			assert.Equal(tt.warning, warnings.String())
+		})
+	}
+}
+
+func TestApplicationServiceInstallConcurrently(t *testing.T) {
+	assert := require.New(t)
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	downloads := 0
+	mu := sync.Mutex{}
+	fileServer := http.FileServer(http.Dir("./testdata"))
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		if r.Header.Get("If-Modified-Since") == "" {
+			downloads++
+		}
+		mu.Unlock()
+		fileServer.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	ctx := context.Background()
+	app := NewApplicationServiceForTest(ctx, t)
+	query, err := ParseQuery(server.URL + "/test.gz")
+	assert.NoError(err)
+	pkg, err := app.Search(ctx, query, NewPlatform("linux", "amd64"))
+	assert.NoError(err)
+
+	dirs := []string{}
+	for i := 0; i < 8; i++ {
+		dirs = append(dirs, t.TempDir())
+	}
+	err = RunConcurrently(len(dirs), 4, func(i int) error {
+		_, err := app.Install(pkg, dirs[i], io.Discard)
+		return err
+	})
+	assert.NoError(err)
+	// same asset is downloaded only once. Others only revalidate cached one.
+	assert.Equal(1, downloads)
+
+	installed, err := app.ListInstalledPackages()
+	assert.NoError(err)
+	assert.Len(installed, len(dirs))
+}
+
+func TestApplicationServiceSelectExecBinaries(t *testing.T) {
+	server := httptest.NewServer(http.FileServer(http.Dir("./testdata")))
+	defer server.Close()
+	indexPath := filepath.Join(t.TempDir(), "index.yaml")
+	index := "- owner: shibataka000\n  repo: multi\n  execBinaries:\n  - name: foo\n  - name: bar\n"
+	require.NoError(t, os.WriteFile(indexPath, []byte(index), 0644))
+
+	platform := NewPlatform("linux", "amd64")
+	fromURL := New(Repository{}, Release{}, NewAsset(NewURL(server.URL+"/multi.tar.gz")), NewExecBinary("multi"), Checksum{})
+	fromURL.Source = SourceURL
+	fromURL.Platform = platform
+	fromIndex := New(NewRepository("shibataka000", "multi"), NewRelease("v1.0.0"), NewAsset(NewURL(server.URL+"/multi.tar.gz")), NewExecBinary("foo"), Checksum{})
+	fromIndex.Platform = platform
+
+	tests := []struct {
+		name         string
+		pkg          Package
+		names        []FileName
+		all          bool
+		execBinaries []FileName
+	}{
+		{
+			name:         "all",
+			pkg:          fromURL,
+			all:          true,
+			execBinaries: []FileName{"bar", "foo"},
+		},
+		{
+			name:         "names",
+			pkg:          fromURL,
+			names:        []FileName{"foo", "bar"},
+			execBinaries: []FileName{"foo", "bar"},
+		},
+		{
+			name:         "index",
+			pkg:          fromIndex,
+			execBinaries: []FileName{"foo", "bar"},
+		},
+		{
+			name:         "executable binary other than index",
+			pkg:          fromIndex.WithExecBinary(NewExecBinary("bar")),
+			execBinaries: []FileName{"bar"},
+		},
+		{
+			name:         "none",
+			pkg:          fromURL,
+			execBinaries: []FileName{"multi"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			t.Setenv("XDG_DATA_HOME", t.TempDir())
+			t.Setenv("XDG_CACHE_HOME", t.TempDir())
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			ctx := context.Background()
+			repository, err := NewInfrastructureRepository(ctx, InfrastructureOptions{IndexPaths: []string{indexPath}})
+			assert.NoError(err)
+			app := NewApplicationService(repository, NewFactory(), InstallOptions{})
+
+			pkgs, err := app.SelectExecBinaries(tt.pkg, tt.names, tt.all, io.Discard)
+			assert.NoError(err)
+			execBinaries := []FileName{}
+			for _, pkg := range pkgs {
+				assert.Equal(tt.pkg.Asset, pkg.Asset)
+				execBinaries = append(execBinaries, pkg.ExecBinary.Name)
+			}
+			assert.Equal(tt.execBinaries, execBinaries)
+
+			if !tt.all {
+				return
+			}
+			dir := t.TempDir()
+			for _, pkg := range pkgs {
+				installation, err := app.Install(pkg, dir, io.Discard)
+				assert.NoError(err)
+				contents, err := os.ReadFile(installation.Path)
+				assert.NoError(err)
+				assert.Equal([]byte(pkg.ExecBinary.Name.String()+"\n"), contents)
+			}
+		})
+	}
+}
+
+func TestApplicationServicePruneCache(t *testing.T) {
+	tests := []struct {
+		name    string
+		before  time.Duration
+		removed int
+	}{
+		{
+			name:    "used after",
+			before:  -time.Hour,
+			removed: 0,
+		},
+		{
+			name:    "unused since",
+			before:  time.Hour,
+			removed: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			server := NewTestDataServerForTest(t, nil)
+			ctx := context.Background()
+			app := NewApplicationServiceForTest(ctx, t)
+			file, err := app.repository.DownloadToCache(NewURL(server.URL+"/test.gz"), "", io.Discard)
+			assert.NoError(err)
+			assert.NoError(file.Close())
+
+			removed, err := app.PruneCache(time.Now().Add(tt.before))
+			assert.NoError(err)
+			assert.Len(removed, tt.removed)
+			assets, err := app.ListCachedAssets()
+			assert.NoError(err)
+			assert.Len(assets, 1-tt.removed)
+
+			assert.NoError(app.CleanCache())
+			assets, err = app.ListCachedAssets()
+			assert.NoError(err)
+			assert.Empty(assets)
+		})
+	}
+}
+
+func TestApplicationServiceOffline(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		asset string
+		err   error
+	}{
+		{
+			name:  "cached release",
+			query: "gitea:shibataka000/hello=v0.1.0",
+		},
+		{
+			name:  "uncached release",
+			query: "gitea:shibataka000/hello",
+			err:   ErrNotCached,
+		},
+		{
+			name:  "cached asset",
+			asset: "/test.gz",
+		},
+		{
+			name:  "uncached asset",
+			asset: "/test.tar.gz",
+			err:   ErrNotCached,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assetServer := NewTestDataServerForTest(t, nil)
+			giteaServer := newFakeGiteaServer(t)
+			ctx := context.Background()
+			platform := NewPlatform("linux", "amd64")
+
+			// release and asset are cached while online.
+			online, err := NewInfrastructureRepository(ctx, InfrastructureOptions{GiteaURL: giteaServer.URL, GiteaToken: "test-token"})
+			assert.NoError(err)
+			app := NewApplicationService(online, NewFactory(), InstallOptions{})
+			giteaQuery, err := ParseQuery("gitea:shibataka000/hello=v0.1.0")
+			assert.NoError(err)
+			expected, err := app.Search(ctx, giteaQuery, platform)
+			assert.NoError(err)
+			pkg, err := app.Search(ctx, NewQueryFromURL(NewURL(assetServer.URL+"/test.gz")), platform)
+			assert.NoError(err)
+			_, err = app.Install(pkg, t.TempDir(), io.Discard)
+			assert.NoError(err)
+
+			giteaServer.Close()
+			assetServer.Close()
+			offline, err := NewInfrastructureRepository(ctx, InfrastructureOptions{GiteaURL: giteaServer.URL, GiteaToken: "test-token", Offline: true})
+			assert.NoError(err)
+			app = NewApplicationService(offline, NewFactory(), InstallOptions{})
+
+			if tt.query != "" {
+				query, err := ParseQuery(tt.query)
+				assert.NoError(err)
+				actual, err := app.Search(ctx, query, platform)
+				if tt.err != nil {
+					assert.ErrorIs(err, tt.err)
+					return
+				}
+				assert.NoError(err)
+				assert.Equal(expected, actual)
+				return
+			}
+
+			pkg, err = app.Search(ctx, NewQueryFromURL(NewURL(assetServer.URL+tt.asset)), platform)
+			assert.NoError(err)
+			installation, err := app.Install(pkg, t.TempDir(), io.Discard)
+			if tt.err != nil {
+				assert.ErrorIs(err, tt.err)
+				return
+			}
+			assert.NoError(err)
+			contents, err := os.ReadFile(installation.Path)
+			assert.NoError(err)
+			assert.Equal([]byte("helloworld\n"), contents)
+		})
+	}
+}
+
+func TestApplicationServiceInstallExtras(t *testing.T) {
+	tests := []struct {
+		name      string
+		existing  []byte
+		overwrite bool
+		modify    bool
+		err       bool
+	}{
+		{
+			name: "install",
+		},
+		{
+			name:     "existing file",
+			existing: []byte("other\n"),
+			err:      true,
+		},
+		{
+			name:      "existing file with overwrite",
+			existing:  []byte("other\n"),
+			overwrite: true,
+		},
+		{
+			name:     "existing file with same contents",
+			existing: []byte("# zsh\n"),
+		},
+		{
+			name:   "modified after installed",
+			modify: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			server := NewTestDataServerForTest(t, nil)
+			dir := t.TempDir()
+			dataHome := os.Getenv("XDG_DATA_HOME")
+
+			extras := []string{
+				filepath.Join(dataHome, "bash-completion", "completions", "tool"),
+				filepath.Join(dataHome, "zsh", "site-functions", "_tool"),
+				filepath.Join(dataHome, "fish", "vendor_completions.d", "tool.fish"),
+				filepath.Join(dataHome, "man", "man1", "tool.1"),
+				filepath.Join(dataHome, "go-get-release", "licenses", "tool", "LICENSE"),
+			}
+			if tt.existing != nil {
+				assert.NoError(os.MkdirAll(filepath.Dir(extras[1]), 0755))
+				assert.NoError(os.WriteFile(extras[1], tt.existing, 0644))
+			}
+
+			ctx := context.Background()
+			app := NewApplicationServiceForTest(ctx, t)
+			query, err := ParseQuery(server.URL + "/extras.tar.gz")
+			assert.NoError(err)
+			query.ExecBinary = "tool"
+			query.Extras = true
+			query.OverwriteExtras = tt.overwrite
+			pkg, err := app.Search(ctx, query, NewPlatform("linux", "amd64"))
+			assert.NoError(err)
+			installation, err := app.Install(pkg, dir, io.Discard)
+			if tt.err {
+				// nothing is installed if any extra file would overwrite file which is not owned by go-get-release.
+				assert.Error(err)
+				assert.NoFileExists(filepath.Join(dir, "tool"))
+				assert.NoFileExists(extras[0])
+				contents, err := os.ReadFile(extras[1])
+				assert.NoError(err)
+				assert.Equal(tt.existing, contents)
+				return
+			}
+			assert.NoError(err)
+			paths := []string{}
+			for _, extra := range installation.Extras {
+				paths = append(paths, extra.Path)
+				file, err := os.ReadFile(extra.Path)
+				assert.NoError(err)
+				assert.Equal(NewFile("", file).SHA256(), extra.SHA256)
+			}
+			assert.ElementsMatch(extras, paths)
+
+			// extras are recorded in inventory and removed with executable binary.
+			installed, err := app.ListInstalledPackages()
+			assert.NoError(err)
+			assert.Len(installed, 1)
+			assert.Equal(installation.Extras, installed[0].Extras)
+			assert.True(installed[0].Query().Extras)
+			if tt.modify {
+				assert.NoError(os.WriteFile(extras[0], []byte("modified\n"), 0644))
+				assert.Error(app.Uninstall(installed[0], false))
+				assert.FileExists(installation.Path)
+				for _, extra := range extras {
+					assert.FileExists(extra)
+				}
+			}
+			assert.NoError(app.Uninstall(installed[0], tt.modify))
+			assert.NoFileExists(installation.Path)
+			for _, extra := range extras {
+				assert.NoFileExists(extra)
+			}
+		})
+	}
+}
+
+func TestApplicationServiceInstallExecBinaryPattern(t *testing.T) {
+	tests := []struct {
+		name  string
+		index string
+	}{
+		{
+			name:  "glob",
+			index: "- owner: shibataka000\n  repo: versioned\n  execBinary:\n    name: tool\n    path: \"tool_v{{.SemVer}}_{{.OS}}_{{.Arch}}\"\n",
+		},
+		{
+			name:  "regexp",
+			index: "- owner: shibataka000\n  repo: versioned\n  execBinary:\n    name: tool\n    regexp: \"^tool_{{.Tag}}_{{.OS}}_{{.Arch}}$\"\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			dir := t.TempDir()
+			t.Setenv("XDG_DATA_HOME", t.TempDir())
+			t.Setenv("XDG_CACHE_HOME", t.TempDir())
+			t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+			server := httptest.NewServer(http.FileServer(http.Dir("./testdata")))
+			defer server.Close()
+			indexPath := filepath.Join(t.TempDir(), "index.yaml")
+			assert.NoError(os.WriteFile(indexPath, []byte(tt.index), 0644))
+
+			ctx := context.Background()
+			repository, err := NewInfrastructureRepository(ctx, InfrastructureOptions{IndexPaths: []string{indexPath}})
+			assert.NoError(err)
+			app := NewApplicationService(repository, NewFactory(), InstallOptions{})
+
+			pkg := New(NewRepository("shibataka000", "versioned"), NewRelease("v1.2.3"), NewAsset(NewURL(server.URL+"/versioned.tar.gz")), NewExecBinary("tool"), Checksum{})
+			pkg.Platform = NewPlatform("linux", "amd64")
+			pkgs, err := app.SelectExecBinaries(pkg, []FileName{"tool"}, false, io.Discard)
+			assert.NoError(err)
+			assert.Len(pkgs, 1)
+			assert.False(pkgs[0].ExecBinary.Pattern.IsEmpty())
+
+			installation, err := app.Install(pkgs[0], dir, io.Discard)
+			assert.NoError(err)
+			assert.Equal(filepath.Join(dir, "tool"), installation.Path)
+			contents, err := os.ReadFile(installation.Path)
+			assert.NoError(err)
+			assert.Equal([]byte("tool\n"), contents)
+
+			// pattern is recorded in lock file so that frozen install finds same file.
+			locked := NewPackageInLockFromInstallation(installation)
+			assert.Equal(pkgs[0].ExecBinary, locked.Package().ExecBinary)
+		})
+	}
+}
+
+func TestApplicationServiceUninstall(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   bool
+		force    bool
+		err      bool
+		removed  bool
+		recorded bool
+	}{
+		{
+			name:     "installed",
+			removed:  true,
+			recorded: false,
+		},
+		{
+			name:     "modified",
+			modify:   true,
+			err:      true,
+			removed:  false,
+			recorded: true,
+		},
+		{
+			name:     "modified with force",
+			modify:   true,
+			force:    true,
+			removed:  true,
+			recorded: false,
+		},
+	}
+
+	server := httptest.NewServer(http.FileServer(http.Dir("./testdata")))
+	defer server.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			dir := t.TempDir()
+			t.Setenv("XDG_DATA_HOME", t.TempDir())
+			t.Setenv("XDG_CACHE_HOME", t.TempDir())
+			ctx := context.Background()
+			app := NewApplicationServiceForTest(ctx, t)
+			locked := NewPackageInLock("shibataka000", "go-get-release-test", "v0.0.1", NewURL(server.URL+"/test.gz"), "test", "8cd07f3a5ff98f2a78cfc366c13fb123eb8d29c1ca37c79df190425d5b9e424d")
+			installation, err := app.InstallFrozen(locked, dir, io.Discard)
+			assert.NoError(err)
+			if tt.modify {
+				assert.NoError(os.WriteFile(installation.Path, []byte("modified"), 0755))
+			}
+
+			pkgs, err := app.FindInstalledPackages([]string{"test"})
+			assert.NoError(err)
+			assert.Len(pkgs, 1)
+			err = app.Uninstall(pkgs[0], tt.force)
+			if tt.err {
+				assert.Error(err)
+			} else {
+				assert.NoError(err)
+			}
+
+			if tt.removed {
+				assert.NoFileExists(installation.Path)
+			} else {
+				assert.FileExists(installation.Path)
+			}
+			installed, err := app.ListInstalledPackages()
+			assert.NoError(err)
+			assert.Equal(tt.recorded, len(installed) == 1)
+		})
+	}
+}
+
 func TestApplicationServiceSearch(t *testing.T) {
 	tests := []struct {
 		query       string
@@ -725,6 +1562,56 @@ func TestParseQuery(t *testing.T) {
 			queryStr: "go-get-release",
 			query:    NewQuery(NewRepository("", "go-get-release"), ""),
 		},
+		{
+			name:     "shibataka000/go-get-release@^1.4",
+			queryStr: "shibataka000/go-get-release@^1.4",
+			query: Query{
+				Repository: NewRepository("shibataka000", "go-get-release"),
+				Constraint: "^1.4",
+			},
+		},
+		{
+			name:     "go-get-release@>=2.0 <3.0",
+			queryStr: "go-get-release@>=2.0 <3.0",
+			query: Query{
+				Repository: NewRepository("", "go-get-release"),
+				Constraint: ">=2.0 <3.0",
+			},
+		},
+		{
+			name:     "gitlab:gitlab-org/cli/glab=v1.30.0",
+			queryStr: "gitlab:gitlab-org/cli/glab=v1.30.0",
+			query: Query{
+				Source:     SourceGitLab,
+				Repository: NewRepository("gitlab-org/cli", "glab"),
+				Tag:        "v1.30.0",
+			},
+		},
+		{
+			name:     "https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_linux_amd64.zip",
+			queryStr: "https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_linux_amd64.zip",
+			query: Query{
+				Source: SourceURL,
+				URL:    "https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_linux_amd64.zip",
+			},
+		},
+		{
+			name:     "codeberg:forgejo/forgejo=v1.20.0",
+			queryStr: "codeberg:forgejo/forgejo=v1.20.0",
+			query: Query{
+				Source:     SourceCodeberg,
+				Repository: NewRepository("forgejo", "forgejo"),
+				Tag:        "v1.20.0",
+			},
+		},
+		{
+			name:     "github:shibataka000/go-get-release",
+			queryStr: "github:shibataka000/go-get-release",
+			query: Query{
+				Source:     SourceGitHub,
+				Repository: NewRepository("shibataka000", "go-get-release"),
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -788,3 +1675,89 @@ func TestQueryHasTag(t *testing.T) {
 		})
 	}
 }
+
+func TestQueryHasExecBinary(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         Query
+		hasExecBinary bool
+	}{
+		{
+			name: "cli/cli with gh",
+			query: Query{
+				Repository: NewRepository("cli", "cli"),
+				ExecBinary: "gh",
+			},
+			hasExecBinary: true,
+		},
+		{
+			name:          "cli/cli",
+			query:         NewQuery(NewRepository("cli", "cli"), ""),
+			hasExecBinary: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.hasExecBinary, tt.query.HasExecBinary())
+		})
+	}
+}
+
+func TestParseQueryError(t *testing.T) {
+	tests := []struct {
+		name     string
+		queryStr string
+	}{
+		{
+			name:     "invalid constraint",
+			queryStr: "shibataka000/go-get-release@^x.y",
+		},
+		{
+			name:     "too many slashes",
+			queryStr: "a/b/c",
+		},
+		{
+			name:     "unknown source",
+			queryStr: "unknown:a/b",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			_, err := ParseQuery(tt.queryStr)
+			assert.Error(err)
+		})
+	}
+}
+
+func TestQueryHasConstraint(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         Query
+		hasConstraint bool
+	}{
+		{
+			name: "shibataka000/go-get-release@^1.4",
+			query: Query{
+				Repository: NewRepository("shibataka000", "go-get-release"),
+				Constraint: "^1.4",
+			},
+			hasConstraint: true,
+		},
+		{
+			name:          "shibataka000/go-get-release",
+			query:         NewQuery(NewRepository("shibataka000", "go-get-release"), ""),
+			hasConstraint: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.hasConstraint, tt.query.HasConstraint())
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package pkg
+
+import (
+	"time"
+)
+
+// AssetInCache is metadata of asset cached in local download cache.
+// ExpectedDigest is digest found in checksum file when asset was downloaded. Asset is cached separately per expected digest.
+// Validator is ETag or Last-Modified of asset, which is used to revalidate asset without expected digest.
+type AssetInCache struct {
+	URL            URL       `json:"url"`
+	ExpectedDigest Digest    `json:"expectedDigest,omitempty"`
+	Validator      string    `json:"validator,omitempty"`
+	SHA256         Digest    `json:"sha256"`
+	Size           int64     `json:"size"`
+	Path           string    `json:"-"`
+	DownloadedAt   time.Time `json:"downloadedAt"`
+	LastUsedAt     time.Time `json:"lastUsedAt"`
+}
+
+// NewAssetInCache return new cached asset metadata instance.
+// path is file path of cached asset.
+func NewAssetInCache(url URL, sha256 Digest, size int64, path string, downloadedAt time.Time, lastUsedAt time.Time) AssetInCache {
+	return AssetInCache{
+		URL:          url,
+		SHA256:       sha256,
+		Size:         size,
+		Path:         path,
+		DownloadedAt: downloadedAt,
+		LastUsedAt:   lastUsedAt,
+	}
+}
+
+// IsUnusedSince return true if cached asset was not used since t.
+func (a AssetInCache) IsUnusedSince(t time.Time) bool {
+	return a.LastUsedAt.Before(t)
+}
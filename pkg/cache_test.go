@@ -0,0 +1,38 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAssetInCacheIsUnusedSince(t *testing.T) {
+	now := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		asset  AssetInCache
+		since  time.Time
+		unused bool
+	}{
+		{
+			name:   "used recently",
+			asset:  NewAssetInCache("https://example.com/tool.tar.gz", "", 0, "", now.Add(-48*time.Hour), now.Add(-time.Hour)),
+			since:  now.Add(-24 * time.Hour),
+			unused: false,
+		},
+		{
+			name:   "not used recently",
+			asset:  NewAssetInCache("https://example.com/tool.tar.gz", "", 0, "", now.Add(-48*time.Hour), now.Add(-36*time.Hour)),
+			since:  now.Add(-24 * time.Hour),
+			unused: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.unused, tt.asset.IsUnusedSince(tt.since))
+		})
+	}
+}
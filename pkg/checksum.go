@@ -0,0 +1,140 @@
+package pkg
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+)
+
+// Checksum is file which has digests of release assets.
+type Checksum struct {
+	DownloadURL URL
+}
+
+// ChecksumFile is checksum file.
+type ChecksumFile File
+
+// Digest is hex encoded digest of file.
+type Digest string
+
+// NewChecksum return new checksum instance.
+func NewChecksum(downloadURL URL) Checksum {
+	return Checksum{
+		DownloadURL: downloadURL,
+	}
+}
+
+// NewDigest return new digest instance.
+func NewDigest(digest string) Digest {
+	return Digest(strings.ToLower(digest))
+}
+
+// IsEmpty return true if checksum is not defined.
+func (c Checksum) IsEmpty() bool {
+	return c.DownloadURL == ""
+}
+
+// Digest find digest of target file in checksum file.
+// Following formats are supported.
+//
+//   - "<digest>  <file name>" or "<digest> *<file name>" (sha256sum)
+//   - "SHA256 (<file name>) = <digest>" (BSD style)
+//   - "<digest>" (checksum file for single file)
+func (f ChecksumFile) Digest(target FileName) (Digest, error) {
+	bsd := regexp.MustCompile(`^[A-Za-z0-9-]+ \((.+)\) = ([0-9A-Fa-f]+)$`)
+	digests := []Digest{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(f.Body))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if submatch := bsd.FindStringSubmatch(line); submatch != nil {
+			if path.Base(submatch[1]) == target.String() {
+				return NewDigest(submatch[2]), nil
+			}
+			continue
+		}
+		fields := strings.Fields(line)
+		switch len(fields) {
+		case 1:
+			digests = append(digests, NewDigest(fields[0]))
+		case 2:
+			name := strings.TrimPrefix(fields[1], "*")
+			if path.Base(name) == target.String() {
+				return NewDigest(fields[0]), nil
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	if len(digests) == 1 {
+		return digests[0], nil
+	}
+	return "", fmt.Errorf("digest of %s was not found in %s", target, f.Name)
+}
+
+// String return string typed digest.
+func (d Digest) String() string {
+	return string(d)
+}
+
+// hash return hash function guessed by length of digest.
+func (d Digest) hash() (hash.Hash, error) {
+	switch len(d) {
+	case hex.EncodedLen(sha256.Size):
+		return sha256.New(), nil
+	case hex.EncodedLen(sha512.Size):
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("%s is unsupported digest", d)
+	}
+}
+
+// SHA256 return SHA-256 digest of file body.
+func (f File) SHA256() Digest {
+	sum := sha256.Sum256(f.Body)
+	return NewDigest(hex.EncodeToString(sum[:]))
+}
+
+// SHA256Reader return SHA-256 digest of contents read from src.
+func SHA256Reader(src io.Reader) (Digest, error) {
+	h := sha256.New()
+	if _, err := io.Copy(h, src); err != nil {
+		return "", err
+	}
+	return NewDigest(hex.EncodeToString(h.Sum(nil))), nil
+}
+
+// Verify return error if file body doesn't match digest.
+func (f File) Verify(digest Digest) error {
+	return VerifyReader(bytes.NewReader(f.Body), f.Name, digest)
+}
+
+// VerifyReader return error if contents read from src doesn't match digest.
+// name is file name used in error message.
+func VerifyReader(src io.Reader, name FileName, digest Digest) error {
+	h, err := digest.hash()
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(h, src); err != nil {
+		return err
+	}
+	actual := NewDigest(hex.EncodeToString(h.Sum(nil)))
+	if actual != digest {
+		return fmt.Errorf("checksum of %s mismatched: expected %s but got %s", name, digest, actual)
+	}
+	return nil
+}
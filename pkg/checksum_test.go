@@ -0,0 +1,127 @@
+package pkg
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestChecksumFileDigest(t *testing.T) {
+	tests := []struct {
+		name     string
+		checksum ChecksumFile
+		target   FileName
+		digest   Digest
+		err      error
+	}{
+		{
+			name:     "sha256sum",
+			checksum: ChecksumFile(NewFile("checksums.txt", []byte("0000000000000000000000000000000000000000000000000000000000000000  test.zip\nc8b0e2e8c7ecdc3ab1ae15e3f8ed11e1a18d2b1ea4bbb2abef2bdf1ffb1e2b54  test.tar.gz\n"))),
+			target:   "test.tar.gz",
+			digest:   "c8b0e2e8c7ecdc3ab1ae15e3f8ed11e1a18d2b1ea4bbb2abef2bdf1ffb1e2b54",
+		},
+		{
+			name:     "sha256sum binary mode",
+			checksum: ChecksumFile(NewFile("SHA256SUMS", []byte("C8B0E2E8C7ECDC3AB1AE15E3F8ED11E1A18D2B1EA4BBB2ABEF2BDF1FFB1E2B54 *./dist/test.tar.gz\n"))),
+			target:   "test.tar.gz",
+			digest:   "c8b0e2e8c7ecdc3ab1ae15e3f8ed11e1a18d2b1ea4bbb2abef2bdf1ffb1e2b54",
+		},
+		{
+			name:     "bsd",
+			checksum: ChecksumFile(NewFile("checksums.txt", []byte("SHA256 (test.tar.gz) = c8b0e2e8c7ecdc3ab1ae15e3f8ed11e1a18d2b1ea4bbb2abef2bdf1ffb1e2b54\n"))),
+			target:   "test.tar.gz",
+			digest:   "c8b0e2e8c7ecdc3ab1ae15e3f8ed11e1a18d2b1ea4bbb2abef2bdf1ffb1e2b54",
+		},
+		{
+			name:     "single file",
+			checksum: ChecksumFile(NewFile("test.tar.gz.sha256", []byte("c8b0e2e8c7ecdc3ab1ae15e3f8ed11e1a18d2b1ea4bbb2abef2bdf1ffb1e2b54\n"))),
+			target:   "test.tar.gz",
+			digest:   "c8b0e2e8c7ecdc3ab1ae15e3f8ed11e1a18d2b1ea4bbb2abef2bdf1ffb1e2b54",
+		},
+		{
+			name:     "not found",
+			checksum: ChecksumFile(NewFile("checksums.txt", []byte("0000000000000000000000000000000000000000000000000000000000000000  test.zip\n"))),
+			target:   "test.tar.gz",
+			err:      fmt.Errorf("digest of test.tar.gz was not found in checksums.txt"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			digest, err := tt.checksum.Digest(tt.target)
+			if tt.err == nil {
+				assert.NoError(err)
+				assert.Equal(tt.digest, digest)
+			} else {
+				assert.EqualError(err, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestFileVerify(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   File
+		digest Digest
+		err    error
+	}{
+		{
+			name:   "sha256",
+			file:   NewFile("test", []byte("helloworld\n")),
+			digest: "8cd07f3a5ff98f2a78cfc366c13fb123eb8d29c1ca37c79df190425d5b9e424d",
+		},
+		{
+			name:   "sha512",
+			file:   NewFile("test", []byte("helloworld\n")),
+			digest: "1f0037260fc650560d0f0a95706956a0e6d1ece501a06835583484c765cbe38a90656d0e1ceaeea7f379e749c0ee49577fbe6989d526c1e653321031d5f0cf18",
+		},
+		{
+			name:   "mismatch",
+			file:   NewFile("test", []byte("helloworld\n")),
+			digest: "0000000000000000000000000000000000000000000000000000000000000000",
+			err:    fmt.Errorf("checksum of test mismatched: expected 0000000000000000000000000000000000000000000000000000000000000000 but got 8cd07f3a5ff98f2a78cfc366c13fb123eb8d29c1ca37c79df190425d5b9e424d"),
+		},
+		{
+			name:   "unsupported",
+			file:   NewFile("test", []byte("helloworld\n")),
+			digest: "0000",
+			err:    fmt.Errorf("0000 is unsupported digest"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			err := tt.file.Verify(tt.digest)
+			if tt.err == nil {
+				assert.NoError(err)
+			} else {
+				assert.EqualError(err, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestFileSHA256(t *testing.T) {
+	tests := []struct {
+		name   string
+		file   File
+		digest Digest
+	}{
+		{
+			name:   "test",
+			file:   NewFile("test", []byte("helloworld\n")),
+			digest: "8cd07f3a5ff98f2a78cfc366c13fb123eb8d29c1ca37c79df190425d5b9e424d",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.digest, tt.file.SHA256())
+		})
+	}
+}
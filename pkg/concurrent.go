@@ -0,0 +1,28 @@
+package pkg
+
+import (
+	"errors"
+	"sync"
+)
+
+// RunConcurrently call f for each of n items with at most jobs goroutines at the same time.
+// Even if f fails for some items, it is called for other items. Errors are joined and returned in order of items.
+func RunConcurrently(n int, jobs int, f func(i int) error) error {
+	if jobs < 1 {
+		jobs = 1
+	}
+	errs := make([]error, n)
+	sem := make(chan struct{}, jobs)
+	wg := sync.WaitGroup{}
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			errs[i] = f(i)
+		}(i)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
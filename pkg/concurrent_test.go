@@ -0,0 +1,82 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunConcurrently(t *testing.T) {
+	tests := []struct {
+		name   string
+		n      int
+		jobs   int
+		failed []int
+	}{
+		{
+			name: "all succeed",
+			n:    10,
+			jobs: 3,
+		},
+		{
+			name:   "some fail",
+			n:      10,
+			jobs:   3,
+			failed: []int{2, 7},
+		},
+		{
+			name: "jobs is zero",
+			n:    3,
+			jobs: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			mu := sync.Mutex{}
+			running, maxRunning := 0, 0
+			called := make([]bool, tt.n)
+			err := RunConcurrently(tt.n, tt.jobs, func(i int) error {
+				mu.Lock()
+				running++
+				if running > maxRunning {
+					maxRunning = running
+				}
+				called[i] = true
+				mu.Unlock()
+				defer func() {
+					mu.Lock()
+					running--
+					mu.Unlock()
+				}()
+				for _, f := range tt.failed {
+					if i == f {
+						return fmt.Errorf("%d failed", i)
+					}
+				}
+				return nil
+			})
+			if tt.jobs > 0 {
+				assert.LessOrEqual(maxRunning, tt.jobs)
+			} else {
+				assert.Equal(1, maxRunning)
+			}
+			for i := range called {
+				assert.True(called[i])
+			}
+			if len(tt.failed) == 0 {
+				assert.NoError(err)
+				return
+			}
+			expected := []error{}
+			for _, f := range tt.failed {
+				expected = append(expected, fmt.Errorf("%d failed", f))
+			}
+			assert.EqualError(err, errors.Join(expected...).Error())
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package pkg
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/mod/semver"
+)
+
+// Constraint is semantic version constraint.
+// For example, "^1.4", "~0.9.2" or ">=2.0 <3.0".
+type Constraint string
+
+// versionRange is range of semantic versions which satisfy a term of constraint.
+// Empty lower or upper means unbounded.
+type versionRange struct {
+	lower          string
+	lowerInclusive bool
+	upper          string
+	upperInclusive bool
+	exclude        string
+}
+
+// NewConstraint return new constraint instance.
+func NewConstraint(constraint string) Constraint {
+	return Constraint(constraint)
+}
+
+// String return string typed constraint.
+func (c Constraint) String() string {
+	return string(c)
+}
+
+// IsEmpty return true if constraint is not specified.
+func (c Constraint) IsEmpty() bool {
+	return strings.TrimSpace(c.String()) == ""
+}
+
+// Validate return error if constraint is invalid.
+func (c Constraint) Validate() error {
+	_, err := c.ranges()
+	return err
+}
+
+// Check return true if release satisfies all terms in constraint.
+// Release whose tag is not valid semver or is pre-release never satisfies constraint.
+func (c Constraint) Check(release Release) (bool, error) {
+	ranges, err := c.ranges()
+	if err != nil {
+		return false, err
+	}
+	v, err := release.SemVer()
+	if err != nil {
+		return false, nil
+	}
+	version := "v" + v
+	if semver.Prerelease(version) != "" {
+		return false, nil
+	}
+	for _, r := range ranges {
+		if !r.contains(version) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ranges parse constraint and return version ranges.
+// Terms are separated by spaces or commas.
+func (c Constraint) ranges() ([]versionRange, error) {
+	terms := strings.FieldsFunc(c.String(), func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+	if len(terms) == 0 {
+		return nil, fmt.Errorf("constraint is empty")
+	}
+	ranges := []versionRange{}
+	for _, term := range terms {
+		r, err := parseConstraintTerm(term)
+		if err != nil {
+			return nil, fmt.Errorf("%s is invalid constraint: %w", c, err)
+		}
+		ranges = append(ranges, r)
+	}
+	return ranges, nil
+}
+
+// parseConstraintTerm parse a term of constraint such as "^1.4" and return version range.
+func parseConstraintTerm(term string) (versionRange, error) {
+	re := regexp.MustCompile(`^(\^|~|>=|<=|>|<|!=|=)?v?(\d+)(\.(\d+))?(\.(\d+))?$`)
+	submatch := re.FindStringSubmatch(term)
+	if submatch == nil {
+		return versionRange{}, fmt.Errorf("%s is invalid term", term)
+	}
+	op := submatch[1]
+	major, _ := strconv.Atoi(submatch[2])
+	minor, _ := strconv.Atoi(submatch[4])
+	patch, _ := strconv.Atoi(submatch[6])
+	hasMinor := submatch[3] != ""
+	hasPatch := submatch[5] != ""
+	version := fmt.Sprintf("v%d.%d.%d", major, minor, patch)
+
+	switch op {
+	case "^":
+		switch {
+		case major != 0 || !hasMinor:
+			return versionRange{lower: version, lowerInclusive: true, upper: fmt.Sprintf("v%d.0.0", major+1)}, nil
+		case minor != 0 || !hasPatch:
+			return versionRange{lower: version, lowerInclusive: true, upper: fmt.Sprintf("v0.%d.0", minor+1)}, nil
+		default:
+			return versionRange{lower: version, lowerInclusive: true, upper: fmt.Sprintf("v0.0.%d", patch+1)}, nil
+		}
+	case "~":
+		if !hasMinor {
+			return versionRange{lower: version, lowerInclusive: true, upper: fmt.Sprintf("v%d.0.0", major+1)}, nil
+		}
+		return versionRange{lower: version, lowerInclusive: true, upper: fmt.Sprintf("v%d.%d.0", major, minor+1)}, nil
+	case ">=":
+		return versionRange{lower: version, lowerInclusive: true}, nil
+	case ">":
+		return versionRange{lower: version}, nil
+	case "<=":
+		return versionRange{upper: version, upperInclusive: true}, nil
+	case "<":
+		return versionRange{upper: version}, nil
+	case "!=":
+		return versionRange{exclude: version}, nil
+	default:
+		return versionRange{lower: version, lowerInclusive: true, upper: version, upperInclusive: true}, nil
+	}
+}
+
+// contains return true if version is in range.
+func (r versionRange) contains(version string) bool {
+	if r.lower != "" {
+		cmp := semver.Compare(version, r.lower)
+		if cmp < 0 || (cmp == 0 && !r.lowerInclusive) {
+			return false
+		}
+	}
+	if r.upper != "" {
+		cmp := semver.Compare(version, r.upper)
+		if cmp > 0 || (cmp == 0 && !r.upperInclusive) {
+			return false
+		}
+	}
+	if r.exclude != "" && semver.Compare(version, r.exclude) == 0 {
+		return false
+	}
+	return true
+}
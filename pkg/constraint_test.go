@@ -0,0 +1,72 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConstraintCheck(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint Constraint
+		release    Release
+		ok         bool
+	}{
+		{name: "^1.4 v1.4.0", constraint: "^1.4", release: NewRelease("v1.4.0"), ok: true},
+		{name: "^1.4 v1.9.3", constraint: "^1.4", release: NewRelease("v1.9.3"), ok: true},
+		{name: "^1.4 v1.3.9", constraint: "^1.4", release: NewRelease("v1.3.9"), ok: false},
+		{name: "^1.4 v2.0.0", constraint: "^1.4", release: NewRelease("v2.0.0"), ok: false},
+		{name: "^0.9 v0.9.5", constraint: "^0.9", release: NewRelease("v0.9.5"), ok: true},
+		{name: "^0.9 v0.10.0", constraint: "^0.9", release: NewRelease("v0.10.0"), ok: false},
+		{name: "^0.0.3 v0.0.4", constraint: "^0.0.3", release: NewRelease("v0.0.4"), ok: false},
+		{name: "~0.9.2 v0.9.8", constraint: "~0.9.2", release: NewRelease("0.9.8"), ok: true},
+		{name: "~0.9.2 v0.9.1", constraint: "~0.9.2", release: NewRelease("v0.9.1"), ok: false},
+		{name: "~0.9.2 v0.10.0", constraint: "~0.9.2", release: NewRelease("v0.10.0"), ok: false},
+		{name: "~1 v1.9.0", constraint: "~1", release: NewRelease("v1.9.0"), ok: true},
+		{name: ">=2.0 <3.0 v2.5.1", constraint: ">=2.0 <3.0", release: NewRelease("v2.5.1"), ok: true},
+		{name: ">=2.0 <3.0 v3.0.0", constraint: ">=2.0 <3.0", release: NewRelease("v3.0.0"), ok: false},
+		{name: ">=2.0, <3.0 v1.9.9", constraint: ">=2.0, <3.0", release: NewRelease("v1.9.9"), ok: false},
+		{name: ">1.0 v1.0.0", constraint: ">1.0", release: NewRelease("v1.0.0"), ok: false},
+		{name: "<=1.0 v1.0.0", constraint: "<=1.0", release: NewRelease("v1.0.0"), ok: true},
+		{name: "!=1.0.1 v1.0.1", constraint: ">=1.0 !=1.0.1", release: NewRelease("v1.0.1"), ok: false},
+		{name: "1.2.3 v1.2.3", constraint: "1.2.3", release: NewRelease("v1.2.3"), ok: true},
+		{name: "pre-release", constraint: "^1.4", release: NewRelease("v1.5.0-rc.1"), ok: false},
+		{name: "invalid semver", constraint: "^1.4", release: NewRelease("nightly"), ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ok, err := tt.constraint.Check(tt.release)
+			assert.NoError(err)
+			assert.Equal(tt.ok, ok)
+		})
+	}
+}
+
+func TestConstraintValidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		constraint Constraint
+		valid      bool
+	}{
+		{name: "^1.4", constraint: "^1.4", valid: true},
+		{name: ">=v2.0.0 <v3", constraint: ">=v2.0.0 <v3", valid: true},
+		{name: "^x", constraint: "^x", valid: false},
+		{name: "=>1.0", constraint: "=>1.0", valid: false},
+		{name: "empty", constraint: "", valid: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			err := tt.constraint.Validate()
+			if tt.valid {
+				assert.NoError(err)
+			} else {
+				assert.Error(err)
+			}
+		})
+	}
+}
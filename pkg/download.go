@@ -0,0 +1,146 @@
+package pkg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// downloadMaxAttempts is max number of attempts to download file.
+const downloadMaxAttempts = 5
+
+// downloadRetryInterval is interval before first retry. It is doubled on each retry.
+var downloadRetryInterval = time.Second
+
+// downloadTimeout is timeout to connect to server and to receive response header.
+var downloadTimeout = 30 * time.Second
+
+// downloadIdleTimeout is timeout to receive next part of response body.
+// Download which stalls longer is aborted and retried.
+var downloadIdleTimeout = time.Minute
+
+// newDownloadClient return HTTP client to download files.
+// It has no timeout of whole request because large file takes long time to be downloaded.
+// Stalled response body is detected by idleTimeoutBody instead.
+func newDownloadClient() *http.Client {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Timeout: downloadTimeout, KeepAlive: 30 * time.Second}).DialContext
+	transport.TLSHandshakeTimeout = downloadTimeout
+	transport.ResponseHeaderTimeout = downloadTimeout
+	return &http.Client{Transport: transport}
+}
+
+// stalledError is returned when no part of response body is received within downloadIdleTimeout.
+// It is net.Error whose Timeout return true, so download is retried.
+type stalledError struct{}
+
+// Error return error message.
+func (stalledError) Error() string {
+	return "download stalled"
+}
+
+// Timeout return true because download stalled too long.
+func (stalledError) Timeout() bool {
+	return true
+}
+
+// Temporary return true because download may succeed when it is retried.
+func (stalledError) Temporary() bool {
+	return true
+}
+
+// idleTimeoutBody is response body which cancels its request if no part of it is received within timeout.
+type idleTimeoutBody struct {
+	body    io.ReadCloser
+	timeout time.Duration
+	timer   *time.Timer
+	cancel  context.CancelFunc
+	stalled atomic.Bool
+}
+
+// newIdleTimeoutBody return response body which calls cancel to abort its request if it stalls longer than timeout.
+func newIdleTimeoutBody(body io.ReadCloser, timeout time.Duration, cancel context.CancelFunc) *idleTimeoutBody {
+	b := &idleTimeoutBody{
+		body:    body,
+		timeout: timeout,
+		cancel:  cancel,
+	}
+	b.timer = time.AfterFunc(timeout, func() {
+		b.stalled.Store(true)
+		cancel()
+	})
+	return b
+}
+
+// Read read response body. If request was aborted because it stalled, stalledError is returned.
+func (b *idleTimeoutBody) Read(p []byte) (int, error) {
+	n, err := b.body.Read(p)
+	if b.stalled.Load() {
+		return n, stalledError{}
+	}
+	b.timer.Reset(b.timeout)
+	return n, err
+}
+
+// Close close response body and release its request.
+func (b *idleTimeoutBody) Close() error {
+	b.timer.Stop()
+	b.cancel()
+	return b.body.Close()
+}
+
+// httpStatusError is error returned when server respond unexpected HTTP status.
+type httpStatusError struct {
+	url        URL
+	status     string
+	statusCode int
+}
+
+// newHTTPStatusError return new HTTP status error instance.
+func newHTTPStatusError(url URL, resp *http.Response) *httpStatusError {
+	return &httpStatusError{
+		url:        url,
+		status:     resp.Status,
+		statusCode: resp.StatusCode,
+	}
+}
+
+// Error return error message.
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("%s: unexpected status %s", e.url, e.status)
+}
+
+// isTransient return true if error may not occur when request is retried.
+// Timeouts, connection resets, connection closed on the way, server errors and rate limit are transient.
+// Other errors such as unknown host, invalid TLS certificate or unsupported URL scheme are not.
+func isTransient(err error) bool {
+	var statusErr *httpStatusError
+	if errors.As(err, &statusErr) {
+		return statusErr.statusCode >= http.StatusInternalServerError || statusErr.statusCode == http.StatusTooManyRequests || statusErr.statusCode == http.StatusRequestTimeout
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNRESET) || errors.Is(err, io.ErrUnexpectedEOF)
+}
+
+// retry call f until it succeeds, it returns non-transient error or number of attempts reaches downloadMaxAttempts.
+// Interval between attempts grows exponentially.
+func retry(f func() error) error {
+	interval := downloadRetryInterval
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || !isTransient(err) || attempt >= downloadMaxAttempts {
+			return err
+		}
+		time.Sleep(interval)
+		interval *= 2
+	}
+}
@@ -0,0 +1,253 @@
+package pkg
+
+import (
+	"bytes"
+	"context"
+	"crypto/x509"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfrastructureRepositoryDownloadWithRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		status   int
+		stall    string
+		requests int
+		err      bool
+	}{
+		{
+			name:     "success",
+			failures: 0,
+			requests: 1,
+		},
+		{
+			name:     "retry on server error",
+			failures: 2,
+			status:   http.StatusServiceUnavailable,
+			requests: 3,
+		},
+		{
+			name:     "give up after max attempts",
+			failures: downloadMaxAttempts,
+			status:   http.StatusBadGateway,
+			requests: downloadMaxAttempts,
+			err:      true,
+		},
+		{
+			name:     "retry when response header stalls",
+			failures: 1,
+			stall:    "header",
+			requests: 2,
+		},
+		{
+			name:     "retry when response body stalls",
+			failures: 1,
+			stall:    "body",
+			requests: 2,
+		},
+		{
+			name:     "no retry on not found",
+			failures: 1,
+			status:   http.StatusNotFound,
+			requests: 1,
+			err:      true,
+		},
+	}
+
+	interval, timeout, idleTimeout := downloadRetryInterval, downloadTimeout, downloadIdleTimeout
+	downloadRetryInterval, downloadTimeout, downloadIdleTimeout = time.Millisecond, 100*time.Millisecond, 100*time.Millisecond
+	defer func() {
+		downloadRetryInterval, downloadTimeout, downloadIdleTimeout = interval, timeout, idleTimeout
+	}()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			var requests atomic.Int32
+			stalled := make(chan struct{})
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch {
+				case int(requests.Add(1)) > tt.failures:
+				case tt.stall == "header":
+					<-stalled
+					return
+				case tt.stall == "body":
+					w.Header().Set("Content-Length", "11")
+					_, _ = w.Write([]byte("hello"))
+					w.(http.Flusher).Flush()
+					<-stalled
+					return
+				default:
+					w.WriteHeader(tt.status)
+					return
+				}
+				_, _ = w.Write([]byte("helloworld\n"))
+			}))
+			defer server.Close()
+			defer close(stalled)
+
+			ctx := context.Background()
+			repository := NewInfrastructureRepositoryForTest(ctx, t)
+			file, err := repository.Download(NewURL(server.URL+"/test"), io.Discard)
+			assert.Equal(tt.requests, int(requests.Load()))
+			if tt.err {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(NewFile("test", []byte("helloworld\n")), file)
+		})
+	}
+}
+
+func TestInfrastructureRepositoryDownloadToCacheResume(t *testing.T) {
+	tests := []struct {
+		name   string
+		etag   []string
+		ranges []string
+	}{
+		{
+			name:   "resume",
+			etag:   []string{`"v1"`, `"v1"`},
+			ranges: []string{"", "bytes=4000-"},
+		},
+		{
+			name:   "changed on the way",
+			etag:   []string{`"v1"`, `"v2"`},
+			ranges: []string{"", "bytes=4000-"},
+		},
+		{
+			name:   "no validator",
+			etag:   []string{"", ""},
+			ranges: []string{"", ""},
+		},
+		{
+			name:   "unexpected range",
+			etag:   []string{`"v1"`, `"v1"`, `"v1"`},
+			ranges: []string{"", "bytes=4000-", ""},
+		},
+	}
+
+	interval := downloadRetryInterval
+	downloadRetryInterval = time.Millisecond
+	defer func() { downloadRetryInterval = interval }()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			t.Setenv("XDG_CACHE_HOME", t.TempDir())
+			old := bytes.Repeat([]byte("9876543210"), 1000)
+			body := bytes.Repeat([]byte("0123456789"), 1000)
+			ranges := []string{}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ranges = append(ranges, r.Header.Get("Range"))
+				if tt.etag[len(ranges)-1] != "" {
+					w.Header().Set("ETag", tt.etag[len(ranges)-1])
+				}
+				switch {
+				case len(ranges) == 1:
+					// connection is closed on the way.
+					w.Header().Set("Content-Length", "10000")
+					_, _ = w.Write(old[:4000])
+				case len(ranges) == 2 && tt.name == "unexpected range":
+					w.Header().Set("Content-Range", "bytes 0-9999/10000")
+					w.WriteHeader(http.StatusPartialContent)
+					_, _ = w.Write(old)
+				case tt.name == "resume":
+					http.ServeContent(w, r, "test", time.Time{}, bytes.NewReader(old))
+				default:
+					http.ServeContent(w, r, "test", time.Time{}, bytes.NewReader(body))
+				}
+			}))
+			defer server.Close()
+
+			ctx := context.Background()
+			repository := NewInfrastructureRepositoryForTest(ctx, t)
+			file, err := repository.DownloadToCache(NewURL(server.URL+"/test"), "", io.Discard)
+			assert.NoError(err)
+			defer file.Close()
+			downloaded, err := os.ReadFile(file.Name())
+			assert.NoError(err)
+			if tt.name == "resume" {
+				assert.Equal(old, downloaded)
+			} else {
+				assert.Equal(body, downloaded)
+			}
+			assert.Equal(tt.ranges, ranges)
+		})
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		transient bool
+	}{
+		{
+			name:      "server error",
+			err:       &httpStatusError{statusCode: http.StatusServiceUnavailable},
+			transient: true,
+		},
+		{
+			name:      "rate limit",
+			err:       &httpStatusError{statusCode: http.StatusTooManyRequests},
+			transient: true,
+		},
+		{
+			name:      "not found",
+			err:       &httpStatusError{statusCode: http.StatusNotFound},
+			transient: false,
+		},
+		{
+			name:      "timeout",
+			err:       &url.Error{Op: "Get", URL: "https://example.com", Err: os.ErrDeadlineExceeded},
+			transient: true,
+		},
+		{
+			name:      "connection reset",
+			err:       &url.Error{Op: "Get", URL: "https://example.com", Err: &net.OpError{Op: "read", Err: os.NewSyscallError("read", syscall.ECONNRESET)}},
+			transient: true,
+		},
+		{
+			name:      "connection closed on the way",
+			err:       io.ErrUnexpectedEOF,
+			transient: true,
+		},
+		{
+			name:      "unknown host",
+			err:       &url.Error{Op: "Get", URL: "https://example.com", Err: &net.DNSError{IsNotFound: true}},
+			transient: false,
+		},
+		{
+			name:      "invalid certificate",
+			err:       &url.Error{Op: "Get", URL: "https://example.com", Err: x509.UnknownAuthorityError{}},
+			transient: false,
+		},
+		{
+			name:      "unsupported scheme",
+			err:       &url.Error{Op: "Get", URL: "ftp://example.com", Err: errors.New("unsupported protocol scheme \"ftp\"")},
+			transient: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.transient, isTransient(tt.err))
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package pkg
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrRepositoryNotFound is returned when repository was not found.
+	ErrRepositoryNotFound = errors.New("repository was not found")
+	// ErrReleaseNotFound is returned when release was not found.
+	ErrReleaseNotFound = errors.New("release was not found")
+	// ErrAssetNotFound is returned when release asset for platform was not found.
+	ErrAssetNotFound = errors.New("asset was not found")
+	// ErrExecBinaryNotFound is returned when executable binary was not found in asset.
+	ErrExecBinaryNotFound = errors.New("executable binary was not found")
+	// ErrNotCached is returned when metadata or asset is required in offline mode but it was not cached.
+	ErrNotCached = errors.New("not cached for offline mode")
+	// ErrUnverified is returned when asset can't be verified because its checksum file was not found.
+	ErrUnverified = errors.New("asset can't be verified")
+	// ErrPlatformMismatch is returned when executable binary in asset is not built for requested platform.
+	ErrPlatformMismatch = errors.New("executable binary is not built for platform")
+)
+
+// ExecBinaryCandidatesError is returned when executable binary was not found in asset by its guessed name
+// and multiple executable binaries were detected in asset instead. One of Candidates should be chosen.
+type ExecBinaryCandidatesError struct {
+	Asset      FileName
+	Candidates []FileName
+}
+
+// Error return error message which lists candidates.
+func (e *ExecBinaryCandidatesError) Error() string {
+	candidates := []string{}
+	for _, candidate := range e.Candidates {
+		candidates = append(candidates, candidate.String())
+	}
+	return fmt.Sprintf("%s in %s by name, but found candidates: %s", ErrExecBinaryNotFound, e.Asset, strings.Join(candidates, ", "))
+}
+
+// Unwrap return ErrExecBinaryNotFound.
+func (e *ExecBinaryCandidatesError) Unwrap() error {
+	return ErrExecBinaryNotFound
+}
@@ -0,0 +1,395 @@
+package pkg
+
+import (
+	"bufio"
+	"bytes"
+	"debug/elf"
+	"debug/macho"
+	"debug/pe"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// ExecutableFormat is file format of executable binary.
+type ExecutableFormat string
+
+const (
+	// ExecutableELF is ELF used by Linux and BSDs.
+	ExecutableELF ExecutableFormat = "elf"
+	// ExecutableMachO is Mach-O used by macOS.
+	ExecutableMachO ExecutableFormat = "macho"
+	// ExecutablePE is PE used by Windows.
+	ExecutablePE ExecutableFormat = "pe"
+)
+
+// DetectExecutableFormat return file format if r reads executable binary.
+// Shared libraries, object files and DLLs are not regarded as executable binary.
+func DetectExecutableFormat(r io.ReaderAt) (ExecutableFormat, bool) {
+	if f, err := elf.NewFile(r); err == nil {
+		defer f.Close()
+		return ExecutableELF, isExecutableELF(f)
+	}
+	if f, err := macho.NewFile(r); err == nil {
+		defer f.Close()
+		return ExecutableMachO, f.Type == macho.TypeExec
+	}
+	if f, err := macho.NewFatFile(r); err == nil {
+		defer f.Close()
+		return ExecutableMachO, len(f.Arches) > 0 && f.Arches[0].Type == macho.TypeExec
+	}
+	// pe.NewFile also parses COFF object file without DOS header, so DOS header is required here.
+	dosMagic := make([]byte, 2)
+	if _, err := r.ReadAt(dosMagic, 0); err != nil || string(dosMagic) != "MZ" {
+		return "", false
+	}
+	if f, err := pe.NewFile(r); err == nil {
+		defer f.Close()
+		return ExecutablePE, f.Characteristics&pe.IMAGE_FILE_EXECUTABLE_IMAGE != 0 && f.Characteristics&pe.IMAGE_FILE_DLL == 0
+	}
+	return "", false
+}
+
+// elfDF1PIE is DF_1_PIE flag in DT_FLAGS_1 entry of dynamic section, which marks position independent executable.
+// It is defined here because debug/elf defines it only since Go 1.21.
+const elfDF1PIE = 0x08000000
+
+// isExecutableELF return true if ELF file is executable.
+// Position independent executable has same type as shared library, so it is distinguished by interpreter,
+// or by DF_1_PIE flag if it is statically linked and has no interpreter.
+func isExecutableELF(f *elf.File) bool {
+	switch f.Type {
+	case elf.ET_EXEC:
+		return true
+	case elf.ET_DYN:
+		for _, prog := range f.Progs {
+			if prog.Type == elf.PT_INTERP {
+				return true
+			}
+		}
+		return elfFlags1(f)&elfDF1PIE != 0
+	default:
+		return false
+	}
+}
+
+// elfFlags1 return value of DT_FLAGS_1 entry in dynamic section of ELF file, or 0 if it doesn't have one.
+// Dynamic section is read through PT_DYNAMIC segment because stripped executable binary may have no section header.
+func elfFlags1(f *elf.File) uint64 {
+	entrySize := 16
+	if f.Class == elf.ELFCLASS32 {
+		entrySize = 8
+	}
+	for _, prog := range f.Progs {
+		if prog.Type != elf.PT_DYNAMIC {
+			continue
+		}
+		r := prog.Open()
+		entry := make([]byte, entrySize)
+		for {
+			if _, err := io.ReadFull(r, entry); err != nil {
+				return 0
+			}
+			var tag, value uint64
+			if f.Class == elf.ELFCLASS32 {
+				tag, value = uint64(f.ByteOrder.Uint32(entry[:4])), uint64(f.ByteOrder.Uint32(entry[4:]))
+			} else {
+				tag, value = f.ByteOrder.Uint64(entry[:8]), f.ByteOrder.Uint64(entry[8:])
+			}
+			switch elf.DynTag(tag) {
+			case elf.DT_NULL:
+				return 0
+			case elf.DT_FLAGS_1:
+				return value
+			}
+		}
+	}
+	return 0
+}
+
+// hasExecutableMagic return true if head starts with magic number of ELF, Mach-O or PE.
+func hasExecutableMagic(head []byte) bool {
+	if len(head) < 4 {
+		return false
+	}
+	if bytes.HasPrefix(head, []byte(elf.ELFMAG)) || bytes.HasPrefix(head, []byte("MZ")) {
+		return true
+	}
+	for _, magic := range []uint32{macho.Magic32, macho.Magic64, macho.MagicFat} {
+		if binary.BigEndian.Uint32(head) == magic || binary.LittleEndian.Uint32(head) == magic {
+			return true
+		}
+	}
+	return false
+}
+
+// DetectExecBinaries detect executable binaries in archived asset read from src by their contents, and return their names.
+// This is used when name of executable binary is unknown. File whose magic number is of ELF, Mach-O or PE is inspected
+// without buffering it in memory. See openExecutableInArchive.
+// If some of detected files have executable permission or ".exe" extension, only they are returned.
+func DetectExecBinaries(src io.ReaderAt, size int64, asset FileName) ([]FileName, error) {
+	all := []FileName{}
+	preferred := []FileName{}
+	err := WalkArchive(src, size, asset, func(path string, mode fs.FileMode, r io.Reader) error {
+		ra, closeFn, err := openExecutableInArchive(r)
+		if err != nil {
+			return err
+		}
+		if ra == nil {
+			return nil
+		}
+		defer closeFn()
+		if _, ok := DetectExecutableFormat(ra); !ok {
+			return nil
+		}
+		name := NewFileName(filepath.Base(path))
+		all = append(all, name)
+		if isExecBinaryInArchive(path, mode) {
+			preferred = append(preferred, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	names := all
+	if len(preferred) > 0 {
+		names = preferred
+	}
+	slices.Sort(names)
+	return slices.Compact(names), nil
+}
+
+// openExecutableInArchive return reader which reads file in archive read from r at random position, and function to close it.
+// If file doesn't start with magic number of ELF, Mach-O or PE, nil is returned.
+// File which is not stored as io.SectionReader, e.g. file in tarball or compressed file in zip file, is copied to temporary file
+// because headers of executable binary may refer to any position in it.
+func openExecutableInArchive(r io.Reader) (io.ReaderAt, func(), error) {
+	head := make([]byte, 4)
+	if sr, ok := r.(*io.SectionReader); ok {
+		if _, err := sr.ReadAt(head, 0); err != nil && err != io.EOF {
+			return nil, nil, err
+		}
+		if !hasExecutableMagic(head) {
+			return nil, nil, nil
+		}
+		return sr, func() {}, nil
+	}
+
+	br := bufio.NewReader(r)
+	head, err := br.Peek(len(head))
+	if err != nil && err != io.EOF {
+		return nil, nil, err
+	}
+	if !hasExecutableMagic(head) {
+		return nil, nil, nil
+	}
+	file, err := os.CreateTemp("", ".go-get-release-*")
+	if err != nil {
+		return nil, nil, err
+	}
+	closeFn := func() {
+		_ = file.Close()
+		_ = os.Remove(file.Name())
+	}
+	if _, err := io.Copy(file, br); err != nil {
+		closeFn()
+		return nil, nil, err
+	}
+	return file, closeFn, nil
+}
+
+// executableTarget is OS and architectures which executable binary is built for.
+// OS is empty if executable binary doesn't record it, e.g. most of ELF files leave OS ABI unspecified.
+// Archs has multiple architectures if executable binary is universal binary of Mach-O.
+type executableTarget struct {
+	format ExecutableFormat
+	os     string
+	archs  []string
+}
+
+// VerifyExecutablePlatform return error if executable binary read from r is not built for platform.
+// If r doesn't read ELF, Mach-O nor PE, e.g. shell script, or OS or architecture is unknown, it is not verified.
+func VerifyExecutablePlatform(r io.ReaderAt, name FileName, platform Platform) error {
+	target, ok := detectExecutableTarget(r)
+	if !ok {
+		return nil
+	}
+	if platform.OS != "" {
+		if format, ok := executableFormatOf(platform.OS); ok && format != target.format {
+			return fmt.Errorf("%w: %s is %s file but %s is requested", ErrPlatformMismatch, name, target.format, platform.OS)
+		}
+		if target.os != "" && target.os != platform.OS {
+			return fmt.Errorf("%w: %s is built for %s but %s is requested", ErrPlatformMismatch, name, target.os, platform.OS)
+		}
+	}
+	if platform.Arch != "" && len(target.archs) > 0 && !slices.Contains(target.archs, platform.Arch) {
+		return fmt.Errorf("%w: %s is built for %s but %s is requested", ErrPlatformMismatch, name, strings.Join(target.archs, ", "), platform.Arch)
+	}
+	return nil
+}
+
+// executableFormatOf return file format of executable binary for os.
+// False is returned for os whose executable binary is neither ELF, Mach-O nor PE.
+func executableFormatOf(os string) (ExecutableFormat, bool) {
+	switch os {
+	case "darwin", "ios":
+		return ExecutableMachO, true
+	case "windows":
+		return ExecutablePE, true
+	case "android", "dragonfly", "freebsd", "illumos", "linux", "netbsd", "openbsd", "solaris":
+		return ExecutableELF, true
+	default:
+		return "", false
+	}
+}
+
+// detectExecutableTarget return OS and architectures which executable binary read from r is built for.
+// It depends only on whether r reads ELF, Mach-O or PE file, not on whether the file is regarded as executable binary
+// by DetectExecutableFormat, so that unusual executable binaries are also verified.
+// Architectures which are unknown to this are ignored.
+func detectExecutableTarget(r io.ReaderAt) (executableTarget, bool) {
+	format, _ := DetectExecutableFormat(r)
+	if format == "" {
+		return executableTarget{}, false
+	}
+	target := executableTarget{
+		format: format,
+		archs:  []string{},
+	}
+	addArch := func(arch string) {
+		if arch != "" && !slices.Contains(target.archs, arch) {
+			target.archs = append(target.archs, arch)
+		}
+	}
+
+	switch format {
+	case ExecutableELF:
+		f, err := elf.NewFile(r)
+		if err != nil {
+			return executableTarget{}, false
+		}
+		defer f.Close()
+		target.os = elfOS(f.OSABI)
+		addArch(elfArch(f))
+	case ExecutableMachO:
+		target.os = "darwin"
+		if f, err := macho.NewFile(r); err == nil {
+			defer f.Close()
+			addArch(machoArch(f.Cpu))
+		} else if f, err := macho.NewFatFile(r); err == nil {
+			defer f.Close()
+			for _, arch := range f.Arches {
+				addArch(machoArch(arch.Cpu))
+			}
+		}
+	case ExecutablePE:
+		f, err := pe.NewFile(r)
+		if err != nil {
+			return executableTarget{}, false
+		}
+		defer f.Close()
+		target.os = "windows"
+		addArch(peArch(f.Machine))
+	}
+	return target, true
+}
+
+// elfOS return OS recorded as OS ABI in ELF header, or empty string if it is not specified.
+func elfOS(osabi elf.OSABI) string {
+	switch osabi {
+	case elf.ELFOSABI_LINUX:
+		return "linux"
+	case elf.ELFOSABI_FREEBSD:
+		return "freebsd"
+	case elf.ELFOSABI_NETBSD:
+		return "netbsd"
+	case elf.ELFOSABI_OPENBSD:
+		return "openbsd"
+	case elf.ELFOSABI_SOLARIS:
+		return "solaris"
+	default:
+		return ""
+	}
+}
+
+// elfArch return GOARCH of ELF file.
+func elfArch(f *elf.File) string {
+	littleEndian := f.Data == elf.ELFDATA2LSB
+	is64 := f.Class == elf.ELFCLASS64
+	switch f.Machine {
+	case elf.EM_386:
+		return "386"
+	case elf.EM_X86_64:
+		return "amd64"
+	case elf.EM_ARM:
+		return "arm"
+	case elf.EM_AARCH64:
+		return "arm64"
+	case elf.EM_RISCV:
+		if is64 {
+			return "riscv64"
+		}
+		return ""
+	case elf.EM_PPC64:
+		if littleEndian {
+			return "ppc64le"
+		}
+		return "ppc64"
+	case elf.EM_S390:
+		return "s390x"
+	case elf.EM_LOONGARCH:
+		return "loong64"
+	case elf.EM_MIPS:
+		arch := "mips"
+		if is64 {
+			arch = "mips64"
+		}
+		if littleEndian {
+			arch += "le"
+		}
+		return arch
+	default:
+		return ""
+	}
+}
+
+// machoArch return GOARCH of Mach-O file.
+func machoArch(cpu macho.Cpu) string {
+	switch cpu {
+	case macho.Cpu386:
+		return "386"
+	case macho.CpuAmd64:
+		return "amd64"
+	case macho.CpuArm:
+		return "arm"
+	case macho.CpuArm64:
+		return "arm64"
+	case macho.CpuPpc64:
+		return "ppc64"
+	default:
+		return ""
+	}
+}
+
+// peArch return GOARCH of PE file.
+func peArch(machine uint16) string {
+	switch machine {
+	case pe.IMAGE_FILE_MACHINE_I386:
+		return "386"
+	case pe.IMAGE_FILE_MACHINE_AMD64:
+		return "amd64"
+	case pe.IMAGE_FILE_MACHINE_ARMNT:
+		return "arm"
+	case pe.IMAGE_FILE_MACHINE_ARM64:
+		return "arm64"
+	default:
+		return ""
+	}
+}
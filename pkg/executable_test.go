@@ -0,0 +1,229 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectExecutableFormat(t *testing.T) {
+	tests := []struct {
+		path       string
+		format     ExecutableFormat
+		executable bool
+	}{
+		{
+			path:       "./testdata/executable/elf",
+			format:     ExecutableELF,
+			executable: true,
+		},
+		{
+			path:       "./testdata/executable/elf-pie",
+			format:     ExecutableELF,
+			executable: true,
+		},
+		{
+			path:       "./testdata/executable/elf-static-pie",
+			format:     ExecutableELF,
+			executable: true,
+		},
+		{
+			path:       "./testdata/executable/elf-shared",
+			format:     ExecutableELF,
+			executable: false,
+		},
+		{
+			path:       "./testdata/executable/macho",
+			format:     ExecutableMachO,
+			executable: true,
+		},
+		{
+			path:       "./testdata/executable/macho-dylib",
+			format:     ExecutableMachO,
+			executable: false,
+		},
+		{
+			path:       "./testdata/executable/pe.exe",
+			format:     ExecutablePE,
+			executable: true,
+		},
+		{
+			path:       "./testdata/executable/pe.dll",
+			format:     ExecutablePE,
+			executable: false,
+		},
+		{
+			path:       "./testdata/test",
+			executable: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert := require.New(t)
+			src, err := os.Open(tt.path)
+			assert.NoError(err)
+			defer src.Close()
+			format, executable := DetectExecutableFormat(src)
+			assert.Equal(tt.format, format)
+			assert.Equal(tt.executable, executable)
+		})
+	}
+}
+
+func TestDetectExecBinaries(t *testing.T) {
+	tests := []struct {
+		assetFilePath string
+		execBinaries  []FileName
+		err           bool
+	}{
+		{
+			assetFilePath: "./testdata/detect.tar.gz",
+			execBinaries:  []FileName{"mytool"},
+		},
+		{
+			assetFilePath: "./testdata/detect.zip",
+			execBinaries:  []FileName{"bar.exe", "foo.exe"},
+		},
+		{
+			assetFilePath: "./testdata/detect-stored.zip",
+			execBinaries:  []FileName{"bar.exe", "foo.exe"},
+		},
+		{
+			assetFilePath: "./testdata/multi.tar.gz",
+			execBinaries:  []FileName{},
+		},
+		{
+			assetFilePath: "./testdata/test.gz",
+			err:           true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.assetFilePath, func(t *testing.T) {
+			assert := require.New(t)
+			src, err := os.Open(tt.assetFilePath)
+			assert.NoError(err)
+			defer src.Close()
+			info, err := src.Stat()
+			assert.NoError(err)
+			execBinaries, err := DetectExecBinaries(src, info.Size(), NewFileName(filepath.Base(tt.assetFilePath)))
+			if tt.err {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.execBinaries, execBinaries)
+		})
+	}
+}
+
+func TestVerifyExecutablePlatform(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		platform Platform
+		err      bool
+	}{
+		{
+			name:     "linux/amd64",
+			path:     "./testdata/executable/elf",
+			platform: NewPlatform("linux", "amd64"),
+		},
+		{
+			name:     "static-pie on linux/amd64",
+			path:     "./testdata/executable/elf-static-pie",
+			platform: NewPlatform("linux", "amd64"),
+		},
+		{
+			name:     "static-pie on linux/arm64",
+			path:     "./testdata/executable/elf-static-pie",
+			platform: NewPlatform("linux", "arm64"),
+			err:      true,
+		},
+		{
+			name:     "static-pie on windows",
+			path:     "./testdata/executable/elf-static-pie",
+			platform: NewPlatform("windows", "amd64"),
+			err:      true,
+		},
+		{
+			name:     "ELF which is not regarded as executable for arm64 on amd64",
+			path:     "./testdata/executable/elf-shared-arm64",
+			platform: NewPlatform("linux", "amd64"),
+			err:      true,
+		},
+		{
+			name:     "ELF on freebsd",
+			path:     "./testdata/executable/elf",
+			platform: NewPlatform("freebsd", "amd64"),
+		},
+		{
+			name:     "ELF for arm64 on amd64",
+			path:     "./testdata/executable/elf-arm64",
+			platform: NewPlatform("linux", "amd64"),
+			err:      true,
+		},
+		{
+			name:     "ELF for freebsd on linux",
+			path:     "./testdata/executable/elf-freebsd",
+			platform: NewPlatform("linux", "amd64"),
+			err:      true,
+		},
+		{
+			name:     "ELF on darwin",
+			path:     "./testdata/executable/elf",
+			platform: NewPlatform("darwin", "amd64"),
+			err:      true,
+		},
+		{
+			name:     "darwin/amd64",
+			path:     "./testdata/executable/macho",
+			platform: NewPlatform("darwin", "amd64"),
+		},
+		{
+			name:     "Mach-O for amd64 on arm64",
+			path:     "./testdata/executable/macho",
+			platform: NewPlatform("darwin", "arm64"),
+			err:      true,
+		},
+		{
+			name:     "windows/amd64",
+			path:     "./testdata/executable/pe.exe",
+			platform: NewPlatform("windows", "amd64"),
+		},
+		{
+			name:     "PE on linux",
+			path:     "./testdata/executable/pe.exe",
+			platform: NewPlatform("linux", "amd64"),
+			err:      true,
+		},
+		{
+			name:     "platform is unknown",
+			path:     "./testdata/executable/elf-arm64",
+			platform: NewPlatform("", ""),
+		},
+		{
+			name:     "not executable binary",
+			path:     "./testdata/test",
+			platform: NewPlatform("windows", "arm64"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			src, err := os.Open(tt.path)
+			assert.NoError(err)
+			defer src.Close()
+			err = VerifyExecutablePlatform(src, NewFileName(filepath.Base(tt.path)), tt.platform)
+			if tt.err {
+				assert.ErrorIs(err, ErrPlatformMismatch)
+				return
+			}
+			assert.NoError(err)
+		})
+	}
+}
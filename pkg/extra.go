@@ -0,0 +1,165 @@
+package pkg
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// ExtraKind is kind of file bundled with executable binary in asset.
+type ExtraKind string
+
+const (
+	// ExtraBashCompletion is bash completion script.
+	ExtraBashCompletion ExtraKind = "bash-completion"
+	// ExtraZshCompletion is zsh completion script.
+	ExtraZshCompletion ExtraKind = "zsh-completion"
+	// ExtraFishCompletion is fish completion script.
+	ExtraFishCompletion ExtraKind = "fish-completion"
+	// ExtraManPage is man page.
+	ExtraManPage ExtraKind = "man"
+	// ExtraLicense is license file.
+	ExtraLicense ExtraKind = "license"
+)
+
+// Extra is file bundled with executable binary in asset, such as shell completion, man page or license file.
+// Path is path of file in archive. In index, it is pattern of filepath.Match.
+type Extra struct {
+	Path string    `yaml:"path"`
+	Kind ExtraKind `yaml:"kind"`
+}
+
+// Extras select files bundled with executable binary in asset to be installed.
+// If Detect is true, extras are detected by their paths in archive.
+// Declared extras, e.g. ones in index, are selected by their path patterns.
+// If Overwrite is true, existing files which were not installed by this application are overwritten.
+type Extras struct {
+	Detect    bool
+	Declared  []Extra
+	Overwrite bool
+}
+
+// InstalledExtra is extra file installed with executable binary.
+// SHA256 is digest of installed file, which is checked before removing it.
+type InstalledExtra struct {
+	Path   string `json:"path"`
+	SHA256 Digest `json:"sha256"`
+}
+
+// NewExtra return new extra instance.
+func NewExtra(path string, kind ExtraKind) Extra {
+	return Extra{
+		Path: path,
+		Kind: kind,
+	}
+}
+
+// NewExtras return new extras instance.
+func NewExtras(detect bool, declared []Extra) Extras {
+	return Extras{
+		Detect:   detect,
+		Declared: declared,
+	}
+}
+
+// NewInstalledExtra return new installed extra instance.
+func NewInstalledExtra(path string, sha256 Digest) InstalledExtra {
+	return InstalledExtra{
+		Path:   path,
+		SHA256: sha256,
+	}
+}
+
+// IsEmpty return true if no extra is selected.
+func (e Extras) IsEmpty() bool {
+	return !e.Detect && len(e.Declared) == 0
+}
+
+// Select return extra if file at path in archive is selected.
+// Declared extras take precedence over detected ones.
+func (e Extras) Select(path string) (Extra, bool) {
+	for _, declared := range e.Declared {
+		if matched, err := filepath.Match(declared.Path, path); err == nil && matched {
+			return NewExtra(path, declared.Kind), true
+		}
+	}
+	if e.Detect {
+		return DetectExtra(path)
+	}
+	return Extra{}, false
+}
+
+// manPagePattern matches file name of man page such as "tool.1" or "tool.1.gz".
+var manPagePattern = regexp.MustCompile(`^[^.].*\.([1-9])[a-z]*(\.gz)?$`)
+
+// DetectExtra detect extra by its path in archive.
+// Shell completions must be in directory whose name contains "complet", or their names must contain it.
+func DetectExtra(path string) (Extra, bool) {
+	base := strings.ToLower(filepath.Base(path))
+	dirs := strings.Split(strings.ToLower(filepath.ToSlash(filepath.Dir(path))), "/")
+	isCompletion := strings.Contains(base, "complet")
+	for _, dir := range dirs {
+		isCompletion = isCompletion || strings.Contains(dir, "complet")
+	}
+
+	switch {
+	case strings.HasPrefix(base, "license") || strings.HasPrefix(base, "licence") || strings.HasPrefix(base, "copying"):
+		return NewExtra(path, ExtraLicense), true
+	case manPagePattern.MatchString(base) && !strings.Contains(base, ".so."):
+		return NewExtra(path, ExtraManPage), true
+	case !isCompletion:
+		return Extra{}, false
+	case strings.HasSuffix(base, ".bash") || strings.HasSuffix(base, ".bash-completion") || slices.Contains(dirs, "bash"):
+		return NewExtra(path, ExtraBashCompletion), true
+	case strings.HasSuffix(base, ".zsh") || strings.HasPrefix(base, "_") || slices.Contains(dirs, "zsh"):
+		return NewExtra(path, ExtraZshCompletion), true
+	case strings.HasSuffix(base, ".fish") || slices.Contains(dirs, "fish"):
+		return NewExtra(path, ExtraFishCompletion), true
+	default:
+		return Extra{}, false
+	}
+}
+
+// InstallPath return path relative to XDG data home where extra is installed.
+// Shell completions are named by their file names, or by executable binary name if their file names are too generic.
+func (e Extra) InstallPath(execBinary ExecBinary) (string, error) {
+	base := filepath.Base(e.Path)
+	name := execBinary.Name.TrimExecExt().String()
+	switch e.Kind {
+	case ExtraBashCompletion:
+		return filepath.Join("bash-completion", "completions", completionName(base, name, ".bash", ".bash-completion")), nil
+	case ExtraZshCompletion:
+		return filepath.Join("zsh", "site-functions", "_"+strings.TrimPrefix(completionName(base, name, ".zsh"), "_")), nil
+	case ExtraFishCompletion:
+		return filepath.Join("fish", "vendor_completions.d", completionName(base, name, ".fish")+".fish"), nil
+	case ExtraManPage:
+		submatch := manPagePattern.FindStringSubmatch(base)
+		if submatch == nil {
+			return "", fmt.Errorf("%s is not man page", e.Path)
+		}
+		return filepath.Join("man", "man"+submatch[1], base), nil
+	case ExtraLicense:
+		return filepath.Join(appName, "licenses", name, base), nil
+	default:
+		return "", fmt.Errorf("%s is unknown kind of extra file", e.Kind)
+	}
+}
+
+// completionName return command name which shell completion file is for.
+// Extensions and "-completion" suffix are trimmed from file name. If nothing meaningful is left, defaultName is returned.
+func completionName(fileName string, defaultName string, exts ...string) string {
+	name := fileName
+	for _, ext := range exts {
+		name = strings.TrimSuffix(name, ext)
+	}
+	for _, suffix := range []string{"-completion", "_completion", ".completion"} {
+		name = strings.TrimSuffix(name, suffix)
+	}
+	if name == "" || name == "_" || strings.Contains(strings.ToLower(name), "complet") {
+		return defaultName
+	}
+	return name
+}
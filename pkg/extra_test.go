@@ -0,0 +1,194 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectExtra(t *testing.T) {
+	tests := []struct {
+		path     string
+		kind     ExtraKind
+		detected bool
+	}{
+		{
+			path:     "gh_2.21.1_linux_amd64/LICENSE",
+			kind:     ExtraLicense,
+			detected: true,
+		},
+		{
+			path:     "COPYING.txt",
+			kind:     ExtraLicense,
+			detected: true,
+		},
+		{
+			path:     "gh_2.21.1_linux_amd64/share/man/man1/gh-pr.1",
+			kind:     ExtraManPage,
+			detected: true,
+		},
+		{
+			path:     "tool.8.gz",
+			kind:     ExtraManPage,
+			detected: true,
+		},
+		{
+			path:     "lib/libtool.so.1",
+			detected: false,
+		},
+		{
+			path:     "completions/tool.bash",
+			kind:     ExtraBashCompletion,
+			detected: true,
+		},
+		{
+			path:     "autocomplete/bash/tool",
+			kind:     ExtraBashCompletion,
+			detected: true,
+		},
+		{
+			path:     "completions/_tool",
+			kind:     ExtraZshCompletion,
+			detected: true,
+		},
+		{
+			path:     "tool-completion.zsh",
+			kind:     ExtraZshCompletion,
+			detected: true,
+		},
+		{
+			path:     "completions/tool.fish",
+			kind:     ExtraFishCompletion,
+			detected: true,
+		},
+		{
+			path:     "scripts/install.bash",
+			detected: false,
+		},
+		{
+			path:     "README.md",
+			detected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			assert := require.New(t)
+			extra, detected := DetectExtra(tt.path)
+			assert.Equal(tt.detected, detected)
+			if tt.detected {
+				assert.Equal(NewExtra(tt.path, tt.kind), extra)
+			}
+		})
+	}
+}
+
+func TestExtrasSelect(t *testing.T) {
+	tests := []struct {
+		name     string
+		extras   Extras
+		path     string
+		extra    Extra
+		selected bool
+	}{
+		{
+			name:     "detect",
+			extras:   NewExtras(true, nil),
+			path:     "completions/tool.bash",
+			extra:    NewExtra("completions/tool.bash", ExtraBashCompletion),
+			selected: true,
+		},
+		{
+			name:     "not detect",
+			extras:   NewExtras(false, nil),
+			path:     "completions/tool.bash",
+			selected: false,
+		},
+		{
+			name:     "declared",
+			extras:   NewExtras(false, []Extra{NewExtra("*/contrib/tool.sh", ExtraBashCompletion)}),
+			path:     "tool/contrib/tool.sh",
+			extra:    NewExtra("tool/contrib/tool.sh", ExtraBashCompletion),
+			selected: true,
+		},
+		{
+			name:     "declared one takes precedence",
+			extras:   NewExtras(true, []Extra{NewExtra("completions/*", ExtraFishCompletion)}),
+			path:     "completions/tool.bash",
+			extra:    NewExtra("completions/tool.bash", ExtraFishCompletion),
+			selected: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			extra, selected := tt.extras.Select(tt.path)
+			assert.Equal(tt.selected, selected)
+			assert.Equal(tt.extra, extra)
+		})
+	}
+}
+
+func TestExtraInstallPath(t *testing.T) {
+	tests := []struct {
+		name  string
+		extra Extra
+		path  string
+		err   bool
+	}{
+		{
+			name:  "bash",
+			extra: NewExtra("completions/gh.bash", ExtraBashCompletion),
+			path:  "bash-completion/completions/gh",
+		},
+		{
+			name:  "bash named by executable binary",
+			extra: NewExtra("completions/bash/completion.bash", ExtraBashCompletion),
+			path:  "bash-completion/completions/tool",
+		},
+		{
+			name:  "zsh",
+			extra: NewExtra("completions/_gh", ExtraZshCompletion),
+			path:  "zsh/site-functions/_gh",
+		},
+		{
+			name:  "zsh with extension",
+			extra: NewExtra("gh-completion.zsh", ExtraZshCompletion),
+			path:  "zsh/site-functions/_gh",
+		},
+		{
+			name:  "fish",
+			extra: NewExtra("completions/gh.fish", ExtraFishCompletion),
+			path:  "fish/vendor_completions.d/gh.fish",
+		},
+		{
+			name:  "man",
+			extra: NewExtra("share/man/man1/gh-pr.1.gz", ExtraManPage),
+			path:  "man/man1/gh-pr.1.gz",
+		},
+		{
+			name:  "license",
+			extra: NewExtra("LICENSE", ExtraLicense),
+			path:  "go-get-release/licenses/tool/LICENSE",
+		},
+		{
+			name:  "unknown kind",
+			extra: NewExtra("LICENSE", "unknown"),
+			err:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			path, err := tt.extra.InstallPath(NewExecBinary("tool.exe"))
+			if tt.err {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.path, path)
+		})
+	}
+}
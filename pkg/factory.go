@@ -29,25 +29,62 @@ func (f *Factory) NewAssetFromIndex(asset AssetInIndex, release Release) (Asset,
 	return NewAsset(downloadURL), nil
 }
 
-// NewAssetFromGitHub return new asset instance from GitHub.
-func (f *Factory) NewAssetFromGitHub(assets []GitHubAsset, platform Platform) (Asset, error) {
-	filtered := FilterGitHubAssetByPlatform(assets, platform)
+// NewAssetFromSource return new asset instance from assets in release source.
+func (f *Factory) NewAssetFromSource(assets []Asset, platform Platform) (Asset, error) {
+	filtered := FilterAssetByPlatform(assets, platform)
 	if len(filtered) == 0 {
-		return Asset{}, fmt.Errorf("asset for %v was not found", platform)
+		return Asset{}, fmt.Errorf("%w for %s/%s", ErrAssetNotFound, platform.OS, platform.Arch)
 	}
-	return Asset(filtered[0]), nil
+	return filtered[0], nil
+}
+
+// NewChecksumFromIndex return new checksum instance from index.
+// If checksum file is not defined in index, empty checksum is returned.
+func (f *Factory) NewChecksumFromIndex(asset AssetInIndex, release Release) (Checksum, error) {
+	if !asset.HasChecksum() {
+		return Checksum{}, nil
+	}
+	downloadURL, err := asset.ChecksumURL.RenderWithRelease(release)
+	if err != nil {
+		return Checksum{}, err
+	}
+	return NewChecksum(downloadURL), nil
+}
+
+// NewChecksumFromSource return new checksum instance from assets in release source.
+// If checksum file is not found in release assets, empty checksum is returned.
+func (f *Factory) NewChecksumFromSource(assets []Asset, asset Asset) Checksum {
+	checksum, err := FindChecksumAsset(assets, asset.DownloadURL.FileName())
+	if err != nil {
+		return Checksum{}
+	}
+	return NewChecksum(checksum.DownloadURL)
 }
 
 // NewExecBinaryFromIndex return executable binary instance from index.
-func (f *Factory) NewExecBinaryFromIndex(execBinary ExecBinaryInIndex, platform Platform) ExecBinary {
-	return f.NewExecBinaryWithPlatform(execBinary.BaseName, platform)
+// Glob and regular expression in index are rendered with release and platform.
+func (f *Factory) NewExecBinaryFromIndex(execBinary ExecBinaryInIndex, release Release, platform Platform) (ExecBinary, error) {
+	b := f.NewExecBinaryWithPlatform(execBinary.BaseName, platform)
+	if !execBinary.HasPattern() {
+		return b, nil
+	}
+	pattern, err := RenderExecBinaryPattern(execBinary.Path, execBinary.Regexp, release, platform)
+	if err != nil {
+		return ExecBinary{}, err
+	}
+	return NewExecBinaryWithPattern(b.Name, pattern), nil
 }
 
-// NewExecBinaryFromGitHub return executable binary instance from GitHub.
-func (f *Factory) NewExecBinaryFromGitHub(repo GitHubRepository, platform Platform) ExecBinary {
+// NewExecBinaryFromRepository return executable binary instance guessed by repository name.
+func (f *Factory) NewExecBinaryFromRepository(repo Repository, platform Platform) ExecBinary {
 	return f.NewExecBinaryWithPlatform(NewFileName(repo.Name), platform)
 }
 
+// NewExecBinaryFromURL return executable binary instance guessed by asset URL.
+func (f *Factory) NewExecBinaryFromURL(url URL, platform Platform) ExecBinary {
+	return f.NewExecBinaryWithPlatform(url.FileName().ExecBinaryBaseName(), platform)
+}
+
 // NewExecBinaryWithPlatform return new executable binary instance.
 // If os is windows, extension ".exe" is added.
 func (f *Factory) NewExecBinaryWithPlatform(baseName FileName, platform Platform) ExecBinary {
@@ -59,7 +59,7 @@ func TestFactoryNewAssetFromIndex(t *testing.T) {
 	}{
 		{
 			name:         "hashicorp/terraform",
-			assetInIndex: NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_linux_amd64.zip", "linux", "amd64"),
+			assetInIndex: NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_linux_amd64.zip", "linux", "amd64", "https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_SHA256SUMS"),
 			release:      NewRelease("v1.0.0"),
 			asset:        NewAsset("https://releases.hashicorp.com/terraform/1.0.0/terraform_1.0.0_linux_amd64.zip"),
 		},
@@ -76,34 +76,34 @@ func TestFactoryNewAssetFromIndex(t *testing.T) {
 	}
 }
 
-func TestFactoryNewAssetFromGitHub(t *testing.T) {
+func TestFactoryNewAssetFromSource(t *testing.T) {
 	tests := []struct {
 		name     string
-		ghAssets []GitHubAsset
+		assets   []Asset
 		platform Platform
 		asset    Asset
 	}{
 		{
 			name: "cli/cli",
-			ghAssets: []GitHubAsset{
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_checksums.txt"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_386.deb"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_386.rpm"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_386.tar.gz"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.deb"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.rpm"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.tar.gz"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_arm64.deb"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_arm64.rpm"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_arm64.tar.gz"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_armv6.deb"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_armv6.rpm"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_armv6.tar.gz"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_macOS_amd64.tar.gz"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_386.zip"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_amd64.msi"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_amd64.zip"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_arm64.zip"),
+			assets: []Asset{
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_checksums.txt"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_386.deb"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_386.rpm"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_386.tar.gz"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.deb"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.rpm"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.tar.gz"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_arm64.deb"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_arm64.rpm"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_arm64.tar.gz"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_armv6.deb"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_armv6.rpm"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_armv6.tar.gz"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_macOS_amd64.tar.gz"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_386.zip"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_amd64.msi"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_amd64.zip"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_arm64.zip"),
 			},
 			platform: NewPlatform("linux", "amd64"),
 			asset:    NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.tar.gz"),
@@ -114,60 +114,179 @@ func TestFactoryNewAssetFromGitHub(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			assert := require.New(t)
 			factory := NewFactory()
-			asset, err := factory.NewAssetFromGitHub(tt.ghAssets, tt.platform)
+			asset, err := factory.NewAssetFromSource(tt.assets, tt.platform)
 			assert.NoError(err)
 			assert.Equal(tt.asset, asset)
 		})
 	}
 }
 
+func TestFactoryNewChecksumFromIndex(t *testing.T) {
+	tests := []struct {
+		name         string
+		assetInIndex AssetInIndex
+		release      Release
+		checksum     Checksum
+	}{
+		{
+			name:         "hashicorp/terraform",
+			assetInIndex: NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_linux_amd64.zip", "linux", "amd64", "https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_SHA256SUMS"),
+			release:      NewRelease("v1.0.0"),
+			checksum:     NewChecksum("https://releases.hashicorp.com/terraform/1.0.0/terraform_1.0.0_SHA256SUMS"),
+		},
+		{
+			name:         "no checksum",
+			assetInIndex: NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_linux_amd64.zip", "linux", "amd64", ""),
+			release:      NewRelease("v1.0.0"),
+			checksum:     Checksum{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			factory := NewFactory()
+			checksum, err := factory.NewChecksumFromIndex(tt.assetInIndex, tt.release)
+			assert.NoError(err)
+			assert.Equal(tt.checksum, checksum)
+		})
+	}
+}
+
+func TestFactoryNewChecksumFromSource(t *testing.T) {
+	tests := []struct {
+		name     string
+		assets   []Asset
+		asset    Asset
+		checksum Checksum
+	}{
+		{
+			name: "cli/cli",
+			assets: []Asset{
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_checksums.txt"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.tar.gz"),
+			},
+			asset:    NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.tar.gz"),
+			checksum: NewChecksum("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_checksums.txt"),
+		},
+		{
+			name: "no checksum",
+			assets: []Asset{
+				NewAsset("https://github.com/aquasecurity/tfsec/releases/download/v1.1.5/tfsec-linux-amd64"),
+			},
+			asset:    NewAsset("https://github.com/aquasecurity/tfsec/releases/download/v1.1.5/tfsec-linux-amd64"),
+			checksum: Checksum{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			factory := NewFactory()
+			checksum := factory.NewChecksumFromSource(tt.assets, tt.asset)
+			assert.Equal(tt.checksum, checksum)
+		})
+	}
+}
+
 func TestFactoryNewExecBinaryFromIndex(t *testing.T) {
 	tests := []struct {
 		name              string
 		execBinaryInIndex ExecBinaryInIndex
+		release           Release
 		platform          Platform
 		execBinary        ExecBinary
+		err               bool
 	}{
 		{
 			name:              "terraform",
 			execBinaryInIndex: NewExecBinaryInIndex("terraform"),
+			release:           NewRelease("v1.5.0"),
 			platform:          NewPlatform("linux", "amd64"),
 			execBinary:        NewExecBinary("terraform"),
 		},
 		{
 			name:              "terraform.exe",
 			execBinaryInIndex: NewExecBinaryInIndex("terraform"),
+			release:           NewRelease("v1.5.0"),
 			platform:          NewPlatform("windows", "amd64"),
 			execBinary:        NewExecBinary("terraform.exe"),
 		},
+		{
+			name:              "glob",
+			execBinaryInIndex: NewExecBinaryInIndexWithPattern("tool", "tool_v{{.SemVer}}_{{.OS}}_{{.Arch}}*", ""),
+			release:           NewRelease("v1.2.3"),
+			platform:          NewPlatform("windows", "amd64"),
+			execBinary:        NewExecBinaryWithPattern("tool.exe", NewExecBinaryPattern("tool_v1.2.3_windows_amd64*", "")),
+		},
+		{
+			name:              "regexp",
+			execBinaryInIndex: NewExecBinaryInIndexWithPattern("tool", "", `^tool_{{.Tag}}_{{.OS}}_{{.Arch}}$`),
+			release:           NewRelease("v1.2.3"),
+			platform:          NewPlatform("linux", "amd64"),
+			execBinary:        NewExecBinaryWithPattern("tool", NewExecBinaryPattern("", `^tool_v1\.2\.3_linux_amd64$`)),
+		},
+		{
+			name:              "regexp with semver",
+			execBinaryInIndex: NewExecBinaryInIndexWithPattern("tool", "", `^tool_{{ .SemVer }}$`),
+			release:           NewRelease("v1.2.3+build.1"),
+			platform:          NewPlatform("linux", "amd64"),
+			execBinary:        NewExecBinaryWithPattern("tool", NewExecBinaryPattern("", `^tool_1\.2\.3\+build\.1$`)),
+		},
+		{
+			name:              "semver is not used",
+			execBinaryInIndex: NewExecBinaryInIndexWithPattern("tool", "tool_{{.Tag}}", ""),
+			release:           NewRelease("nightly"),
+			platform:          NewPlatform("linux", "amd64"),
+			execBinary:        NewExecBinaryWithPattern("tool", NewExecBinaryPattern("tool_nightly", "")),
+		},
+		{
+			name:              "semver is not available",
+			execBinaryInIndex: NewExecBinaryInIndexWithPattern("tool", "tool_{{ .SemVer }}", ""),
+			release:           NewRelease("nightly"),
+			platform:          NewPlatform("linux", "amd64"),
+			err:               true,
+		},
+		{
+			name:              "invalid regexp",
+			execBinaryInIndex: NewExecBinaryInIndexWithPattern("tool", "", "tool_("),
+			release:           NewRelease("v1.2.3"),
+			platform:          NewPlatform("linux", "amd64"),
+			err:               true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			assert := require.New(t)
 			factory := NewFactory()
-			execBinary := factory.NewExecBinaryFromIndex(tt.execBinaryInIndex, tt.platform)
+			execBinary, err := factory.NewExecBinaryFromIndex(tt.execBinaryInIndex, tt.release, tt.platform)
+			if tt.err {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
 			assert.Equal(tt.execBinary, execBinary)
 		})
 	}
 }
 
-func TestFactoryNewExecBinaryFromGitHub(t *testing.T) {
+func TestFactoryNewExecBinaryFromRepository(t *testing.T) {
 	tests := []struct {
 		name       string
-		ghRepo     GitHubRepository
+		repo       Repository
 		platform   Platform
 		execBinary ExecBinary
 	}{
 		{
 			name:       "terraform",
-			ghRepo:     NewGitHubRepository("hashicorp", "terraform"),
+			repo:       NewRepository("hashicorp", "terraform"),
 			platform:   NewPlatform("linux", "amd64"),
 			execBinary: NewExecBinary("terraform"),
 		},
 		{
 			name:       "terraform.exe",
-			ghRepo:     NewGitHubRepository("hashicorp", "terraform"),
+			repo:       NewRepository("hashicorp", "terraform"),
 			platform:   NewPlatform("windows", "amd64"),
 			execBinary: NewExecBinary("terraform.exe"),
 		},
@@ -177,7 +296,7 @@ func TestFactoryNewExecBinaryFromGitHub(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			assert := require.New(t)
 			factory := NewFactory()
-			execBinary := factory.NewExecBinaryFromGitHub(tt.ghRepo, tt.platform)
+			execBinary := factory.NewExecBinaryFromRepository(tt.repo, tt.platform)
 			assert.Equal(tt.execBinary, execBinary)
 		})
 	}
@@ -7,8 +7,9 @@ import (
 	"compress/gzip"
 	"fmt"
 	"io"
-	"os"
+	"io/fs"
 	"path/filepath"
+	"regexp"
 	"sort"
 	"strings"
 
@@ -63,20 +64,14 @@ func NewFileName(name string) FileName {
 // Extract compressed file.
 func (f File) Extract() (File, error) {
 	fileName := f.Name.Normalize()
-	src := bytes.NewReader(f.Body)
-	dst := new(bytes.Buffer)
-	var err error
-
-	switch fileName.Ext() {
-	case ".gz":
-		err = extractGzip(dst, src)
-	case ".xz":
-		err = extractXz(dst, src)
-	default:
-		err = fmt.Errorf("unsupported file format: %s", fileName.Ext())
+	src, err := newExtractReader(bytes.NewReader(f.Body), fileName)
+	if err != nil {
+		return File{}, err
 	}
+	defer src.Close()
 
-	if err != nil {
+	dst := new(bytes.Buffer)
+	if _, err := io.Copy(dst, src); err != nil {
 		return File{}, err
 	}
 
@@ -92,9 +87,9 @@ func (f File) FindFile(target FileName) (File, error) {
 
 	switch fileName.Ext() {
 	case ".tar":
-		err = copyFileInTar(dst, src, target)
+		err = copyFileInTar(dst, src, NewExecBinary(target))
 	case ".zip":
-		err = copyFileInZip(dst, src, target)
+		err = copyFileInZip(dst, src, src.Size(), NewExecBinary(target))
 	default:
 		err = fmt.Errorf("unsupported file format: %s", fileName.Ext())
 	}
@@ -106,31 +101,25 @@ func (f File) FindFile(target FileName) (File, error) {
 	return NewFile(target, dst.Bytes()), nil
 }
 
-// extractGzip extract src as gzip file and copy it to dst.
-func extractGzip(dst io.Writer, src io.Reader) error {
-	gzipSrc, err := gzip.NewReader(src)
-	if err != nil {
-		return err
-	}
-	defer gzipSrc.Close()
-
-	_, err = io.Copy(dst, gzipSrc)
-	return err
-}
-
-// extractXz extract src as xz file and copy it to dst.
-func extractXz(dst io.Writer, src io.Reader) error {
-	xzSrc, err := xz.NewReader(src)
-	if err != nil {
-		return err
+// newExtractReader return reader which extracts compressed src on the fly.
+func newExtractReader(src io.Reader, fileName FileName) (io.ReadCloser, error) {
+	switch fileName.Ext() {
+	case ".gz":
+		return gzip.NewReader(src)
+	case ".xz":
+		xzSrc, err := xz.NewReader(src)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(xzSrc), nil
+	default:
+		return nil, fmt.Errorf("unsupported file format: %s", fileName.Ext())
 	}
-
-	_, err = io.Copy(dst, xzSrc)
-	return err
 }
 
-// copyFileInTar find a file in tarball and copy it to dst.
-func copyFileInTar(dst io.Writer, src io.Reader, target FileName) error {
+// copyFileInTar find a file matching target in tarball and copy it to dst.
+// If multiple files match, first one is copied.
+func copyFileInTar(dst io.Writer, src io.Reader, target ExecBinary) error {
 	tarSrc := tar.NewReader(src)
 
 	for {
@@ -146,7 +135,7 @@ func copyFileInTar(dst io.Writer, src io.Reader, target FileName) error {
 		case tar.TypeDir:
 			// do nothing
 		case tar.TypeReg:
-			if filepath.Base(header.Name) == target.String() {
+			if target.Match(header.Name) {
 				_, err := io.Copy(dst, tarSrc)
 				return err
 			}
@@ -155,30 +144,20 @@ func copyFileInTar(dst io.Writer, src io.Reader, target FileName) error {
 		}
 	}
 
-	return fmt.Errorf("file '%s' was not found in tarball", target)
+	return fmt.Errorf("%w in tarball: %s", ErrExecBinaryNotFound, target)
 }
 
-// copyFileInZip find a file in zip file and copy it to dst.
-func copyFileInZip(dst io.Writer, src io.Reader, target FileName) error {
-	tempFile, err := os.CreateTemp("", "*.zip")
+// copyFileInZip find a file matching target in zip file and copy it to dst.
+// If multiple files match, first one is copied.
+// Zip file is read by io.ReaderAt because its central directory is at the end of file.
+func copyFileInZip(dst io.Writer, src io.ReaderAt, size int64, target ExecBinary) error {
+	zipSrc, err := zip.NewReader(src, size)
 	if err != nil {
 		return err
 	}
-	_, err = io.Copy(tempFile, src)
-	if err != nil {
-		return err
-	}
-	defer os.Remove(tempFile.Name())
-	defer tempFile.Close()
 
-	rc, err := zip.OpenReader(tempFile.Name())
-	if err != nil {
-		return err
-	}
-	defer rc.Close()
-
-	for _, f := range rc.File {
-		if !f.FileInfo().IsDir() && filepath.Base(f.Name) == target.String() {
+	for _, f := range zipSrc.File {
+		if !f.FileInfo().IsDir() && target.Match(f.Name) {
 			fileIn, err := f.Open()
 			if err != nil {
 				return err
@@ -190,33 +169,144 @@ func copyFileInZip(dst io.Writer, src io.Reader, target FileName) error {
 		}
 	}
 
-	return fmt.Errorf("file '%s' was not found in zip file", target)
+	return fmt.Errorf("%w in zip file: %s", ErrExecBinaryNotFound, target)
 }
 
 // ExecBinary return executable binary file in asset file.
 func (f AssetFile) ExecBinary(execBinary FileName) (ExecBinaryFile, error) {
-	file := File(f)
-	var err error
+	dst := new(bytes.Buffer)
+	if err := CopyExecBinary(dst, bytes.NewReader(f.Body), int64(len(f.Body)), f.Name, NewExecBinary(execBinary)); err != nil {
+		return ExecBinaryFile{}, err
+	}
+	return NewExecBinaryFile(execBinary, dst.Bytes()), nil
+}
+
+// CopyExecBinary find executable binary in asset read from src and copy it to dst.
+// Asset is extracted on the fly without buffering whole of it in memory.
+// src is read as io.ReaderAt because zip file can't be read sequentially.
+// File in archive is found by name or pattern of execBinary.
+func CopyExecBinary(dst io.Writer, src io.ReaderAt, size int64, asset FileName, execBinary ExecBinary) error {
+	fileName := asset.Normalize()
+	var r io.Reader = io.NewSectionReader(src, 0, size)
 
-	if file.Name.IsCompressed() && (!file.Name.IsArchived() || file.Name.IsTarBall()) {
-		file, err = file.Extract()
+	if fileName.IsCompressed() && (!fileName.IsArchived() || fileName.IsTarBall()) {
+		extracted, err := newExtractReader(r, fileName)
 		if err != nil {
-			return ExecBinaryFile{}, err
+			return err
+		}
+		defer extracted.Close()
+		r = extracted
+		fileName = fileName.TrimExt()
+	}
+
+	if fileName.IsArchived() {
+		switch fileName.Ext() {
+		case ".tar":
+			return copyFileInTar(dst, r, execBinary)
+		case ".zip":
+			return copyFileInZip(dst, src, size, execBinary)
+		default:
+			return fmt.Errorf("unsupported file format: %s", fileName.Ext())
 		}
 	}
 
-	if file.Name.IsArchived() {
-		file, err = file.FindFile(execBinary)
+	if !fileName.IsExecBinary() {
+		return fmt.Errorf("%w: %s is not executable binary", ErrExecBinaryNotFound, fileName)
+	}
+
+	_, err := io.Copy(dst, r)
+	return err
+}
+
+// ListExecBinaries list names of executable binaries in archived asset read from src.
+// File in archive is regarded as executable binary if it has executable permission or its extension is ".exe".
+func ListExecBinaries(src io.ReaderAt, size int64, asset FileName) ([]FileName, error) {
+	names := []FileName{}
+	err := WalkArchive(src, size, asset, func(path string, mode fs.FileMode, _ io.Reader) error {
+		if isExecBinaryInArchive(path, mode) {
+			names = append(names, NewFileName(filepath.Base(path)))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slices.Sort(names)
+	return slices.Compact(names), nil
+}
+
+// WalkArchive call fn for each regular file in archived asset read from src.
+// path is path of file in archive and r reads its contents.
+func WalkArchive(src io.ReaderAt, size int64, asset FileName, fn func(path string, mode fs.FileMode, r io.Reader) error) error {
+	fileName := asset.Normalize()
+	if !fileName.IsArchived() {
+		return fmt.Errorf("%s is not archive", asset)
+	}
+
+	if !fileName.IsTarBall() {
+		zipSrc, err := zip.NewReader(src, size)
+		if err != nil {
+			return err
+		}
+		for _, f := range zipSrc.File {
+			if !f.Mode().IsRegular() {
+				continue
+			}
+			if err := walkFileInZip(src, f, fn); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var r io.Reader = io.NewSectionReader(src, 0, size)
+	if fileName.IsCompressed() {
+		extracted, err := newExtractReader(r, fileName)
 		if err != nil {
-			return ExecBinaryFile{}, err
+			return err
+		}
+		defer extracted.Close()
+		r = extracted
+	}
+	tarSrc := tar.NewReader(r)
+	for {
+		header, err := tarSrc.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		if err := fn(header.Name, header.FileInfo().Mode(), tarSrc); err != nil {
+			return err
 		}
 	}
+}
 
-	if !file.Name.IsExecBinary() {
-		return ExecBinaryFile{}, fmt.Errorf("%s is not executable binary", file.Name)
+// walkFileInZip call fn for file in zip file read from src.
+// File stored without compression is passed as io.SectionReader of src, so that it can be read at random position without copying it.
+func walkFileInZip(src io.ReaderAt, f *zip.File, fn func(path string, mode fs.FileMode, r io.Reader) error) error {
+	if f.Method == zip.Store {
+		offset, err := f.DataOffset()
+		if err != nil {
+			return err
+		}
+		return fn(f.Name, f.Mode(), io.NewSectionReader(src, offset, int64(f.UncompressedSize64)))
 	}
+	fileIn, err := f.Open()
+	if err != nil {
+		return err
+	}
+	defer fileIn.Close()
+	return fn(f.Name, f.Mode(), fileIn)
+}
 
-	return NewExecBinaryFile(execBinary, file.Body), nil
+// isExecBinaryInArchive return true if file in archive is executable binary.
+func isExecBinaryInArchive(name string, mode fs.FileMode) bool {
+	return mode&0111 != 0 || strings.ToLower(filepath.Ext(name)) == ".exe"
 }
 
 // String return string typed file name.
@@ -240,6 +330,14 @@ func (f FileName) AddExt(ext string) FileName {
 	return NewFileName(fmt.Sprintf("%s.%s", f.String(), strings.TrimPrefix(ext, ".")))
 }
 
+// TrimExecExt trim extension ".exe" from file name if exists and return new FileName instance.
+func (f FileName) TrimExecExt() FileName {
+	if f.Ext() == ".exe" {
+		return f.TrimExt()
+	}
+	return f
+}
+
 // Normalize file name.
 // File name extension '.tgz' and '.txz' will be replaced to '.tar.gz' and '.tar.xz'.
 func (f FileName) Normalize() FileName {
@@ -253,6 +351,30 @@ func (f FileName) Normalize() FileName {
 	}
 }
 
+// ExecBinaryBaseName return executable binary name guessed by asset file name.
+// Extensions, version and platform are trimmed. For example, "tool_1.2.3_linux_amd64.tar.gz" becomes "tool".
+func (f FileName) ExecBinaryBaseName() FileName {
+	name := f.Normalize()
+	for name.IsCompressed() || name.IsTarBall() || name.Ext() == ".exe" {
+		name = name.TrimExt().Normalize()
+	}
+
+	keywords := []string{
+		"linux", "darwin", "macos", "osx", "windows", "win", "freebsd", "netbsd", "openbsd",
+		"amd64", "x86", "x64", "64bit", "386", "arm", "arm64", "aarch64", "armv6", "armv7", "ppc64le", "s390x", "riscv64",
+	}
+	version := regexp.MustCompile(`^v?\d`)
+	separator := regexp.MustCompile(`[-_.]`)
+	s := name.String()
+	for _, loc := range separator.FindAllStringIndex(s, -1) {
+		token := separator.Split(s[loc[1]:], 2)[0]
+		if version.MatchString(token) || slices.Contains(keywords, strings.ToLower(token)) {
+			return NewFileName(s[:loc[0]])
+		}
+	}
+	return NewFileName(s)
+}
+
 // IsExecBinary return true if file is executable binary.
 func (f FileName) IsExecBinary() bool {
 	exts := []string{"", ".exe", ".linux", ".darwin", ".linux-amd64", ".darwin-amd64", ".amd64"}
@@ -277,6 +399,27 @@ func (f FileName) IsTarBall() bool {
 	return slices.Contains(exts, f.Normalize().Ext()) || slices.Contains(exts, f.Normalize().TrimExt().Ext())
 }
 
+// IsChecksumForSingleFile return true if file is checksum file which has digest of single file, e.g. "<file name>.sha256".
+func (f FileName) IsChecksumForSingleFile() bool {
+	exts := []string{".sha256", ".sha256sum", ".sha512", ".sha512sum"}
+	return slices.Contains(exts, strings.ToLower(f.Ext()))
+}
+
+// IsChecksumForMultiFiles return true if file is checksum file which has digests of multiple files, e.g. "checksums.txt" or "SHA256SUMS".
+func (f FileName) IsChecksumForMultiFiles() bool {
+	signatureExts := []string{".sig", ".asc", ".pem", ".minisig", ".bundle"}
+	if slices.Contains(signatureExts, strings.ToLower(f.Ext())) {
+		return false
+	}
+	lower := strings.ToLower(f.String())
+	for _, keyword := range []string{"checksums", "sha256sums", "sha512sums"} {
+		if strings.Contains(lower, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
 // Platform return platform guessed by file name.
 func (f FileName) Platform() (Platform, error) {
 	os, err := f.os()
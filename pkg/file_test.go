@@ -1,6 +1,7 @@
 package pkg
 
 import (
+	"bytes"
 	"os"
 	"path/filepath"
 	"testing"
@@ -50,6 +51,7 @@ func TestFileFindFile(t *testing.T) {
 		testFilePath string
 		target       FileName
 		found        File
+		err          error
 	}{
 		{
 			name:         "test.zip",
@@ -57,6 +59,12 @@ func TestFileFindFile(t *testing.T) {
 			target:       "test",
 			found:        NewFile("test", []byte("helloworld\n")),
 		},
+		{
+			name:         "not found",
+			testFilePath: "./testdata/test.zip",
+			target:       "missing",
+			err:          ErrExecBinaryNotFound,
+		},
 	}
 
 	for _, tt := range tests {
@@ -65,8 +73,12 @@ func TestFileFindFile(t *testing.T) {
 			file, err := ReadTestFile(t, tt.testFilePath)
 			assert.NoError(err)
 			found, err := file.FindFile(tt.target)
-			assert.NoError(err)
-			assert.Equal(tt.found, found)
+			if tt.err == nil {
+				assert.NoError(err)
+				assert.Equal(tt.found, found)
+			} else {
+				assert.ErrorIs(err, tt.err)
+			}
 		})
 	}
 }
@@ -123,6 +135,126 @@ func TestAssetFileExecBinary(t *testing.T) {
 	}
 }
 
+func TestCopyExecBinary(t *testing.T) {
+	tests := []struct {
+		name          string
+		assetFilePath string
+		execBinary    FileName
+		contents      []byte
+		err           error
+	}{
+		{
+			name:          "./testdata/test",
+			assetFilePath: "./testdata/test",
+			execBinary:    "test",
+			contents:      []byte("helloworld\n"),
+		},
+		{
+			name:          "./testdata/test.gz",
+			assetFilePath: "./testdata/test.gz",
+			execBinary:    "test",
+			contents:      []byte("helloworld\n"),
+		},
+		{
+			name:          "./testdata/test.tar.gz",
+			assetFilePath: "./testdata/test.tar.gz",
+			execBinary:    "test",
+			contents:      []byte("helloworld\n"),
+		},
+		{
+			name:          "./testdata/test.tar.xz",
+			assetFilePath: "./testdata/test.tar.xz",
+			execBinary:    "test",
+			contents:      []byte("helloworld\n"),
+		},
+		{
+			name:          "./testdata/test.zip",
+			assetFilePath: "./testdata/test.zip",
+			execBinary:    "test",
+			contents:      []byte("helloworld\n"),
+		},
+		{
+			name:          "not found",
+			assetFilePath: "./testdata/test.tar.gz",
+			execBinary:    "missing",
+			err:           ErrExecBinaryNotFound,
+		},
+		{
+			name:          "not executable binary",
+			assetFilePath: "./testdata/index.yaml",
+			execBinary:    "index",
+			err:           ErrExecBinaryNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			src, err := os.Open(tt.assetFilePath)
+			assert.NoError(err)
+			defer src.Close()
+			info, err := src.Stat()
+			assert.NoError(err)
+			dst := new(bytes.Buffer)
+			err = CopyExecBinary(dst, src, info.Size(), NewFileName(filepath.Base(tt.assetFilePath)), NewExecBinary(tt.execBinary))
+			if tt.err != nil {
+				assert.ErrorIs(err, tt.err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.contents, dst.Bytes())
+		})
+	}
+}
+
+func TestListExecBinaries(t *testing.T) {
+	tests := []struct {
+		name          string
+		assetFilePath string
+		execBinaries  []FileName
+		err           bool
+	}{
+		{
+			name:          "./testdata/multi.tar.gz",
+			assetFilePath: "./testdata/multi.tar.gz",
+			execBinaries:  []FileName{"bar", "foo"},
+		},
+		{
+			name:          "./testdata/multi.zip",
+			assetFilePath: "./testdata/multi.zip",
+			execBinaries:  []FileName{"bar.exe", "foo.exe"},
+		},
+		{
+			name:          "./testdata/test.tar.gz",
+			assetFilePath: "./testdata/test.tar.gz",
+			execBinaries:  []FileName{},
+		},
+		{
+			name:          "./testdata/test.gz",
+			assetFilePath: "./testdata/test.gz",
+			err:           true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			src, err := os.Open(tt.assetFilePath)
+			assert.NoError(err)
+			defer src.Close()
+			info, err := src.Stat()
+			assert.NoError(err)
+			execBinaries, err := ListExecBinaries(src, info.Size(), NewFileName(filepath.Base(tt.assetFilePath)))
+			if tt.err {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.execBinaries, execBinaries)
+		})
+	}
+}
+
 func TestFileNameExt(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -185,6 +317,52 @@ func TestFileNameTrimExt(t *testing.T) {
 	}
 }
 
+func TestFileNameExecBinaryBaseName(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename FileName
+		baseName FileName
+	}{
+		{
+			name:     "terraform_1.5.0_linux_amd64.zip",
+			filename: NewFileName("terraform_1.5.0_linux_amd64.zip"),
+			baseName: NewFileName("terraform"),
+		},
+		{
+			name:     "helm-v3.12.0-linux-amd64.tar.gz",
+			filename: NewFileName("helm-v3.12.0-linux-amd64.tar.gz"),
+			baseName: NewFileName("helm"),
+		},
+		{
+			name:     "go-get-release_linux_amd64.tgz",
+			filename: NewFileName("go-get-release_linux_amd64.tgz"),
+			baseName: NewFileName("go-get-release"),
+		},
+		{
+			name:     "argocd-windows-amd64.exe",
+			filename: NewFileName("argocd-windows-amd64.exe"),
+			baseName: NewFileName("argocd"),
+		},
+		{
+			name:     "kubectl",
+			filename: NewFileName("kubectl"),
+			baseName: NewFileName("kubectl"),
+		},
+		{
+			name:     "test.gz",
+			filename: NewFileName("test.gz"),
+			baseName: NewFileName("test"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.baseName, tt.filename.ExecBinaryBaseName())
+		})
+	}
+}
+
 func TestFileNameAddExt(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -345,3 +523,51 @@ func TestFileNamePlatform(t *testing.T) {
 		})
 	}
 }
+
+func TestFileNameIsChecksum(t *testing.T) {
+	tests := []struct {
+		name                    string
+		filename                FileName
+		isChecksumForSingleFile bool
+		isChecksumForMultiFiles bool
+	}{
+		{
+			name:                    "test.tar.gz.sha256",
+			filename:                NewFileName("test.tar.gz.sha256"),
+			isChecksumForSingleFile: true,
+			isChecksumForMultiFiles: false,
+		},
+		{
+			name:                    "test_checksums.txt",
+			filename:                NewFileName("test_checksums.txt"),
+			isChecksumForSingleFile: false,
+			isChecksumForMultiFiles: true,
+		},
+		{
+			name:                    "test_1.0.0_SHA256SUMS",
+			filename:                NewFileName("test_1.0.0_SHA256SUMS"),
+			isChecksumForSingleFile: false,
+			isChecksumForMultiFiles: true,
+		},
+		{
+			name:                    "test_1.0.0_SHA256SUMS.sig",
+			filename:                NewFileName("test_1.0.0_SHA256SUMS.sig"),
+			isChecksumForSingleFile: false,
+			isChecksumForMultiFiles: false,
+		},
+		{
+			name:                    "test.tar.gz",
+			filename:                NewFileName("test.tar.gz"),
+			isChecksumForSingleFile: false,
+			isChecksumForMultiFiles: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.isChecksumForSingleFile, tt.filename.IsChecksumForSingleFile())
+			assert.Equal(tt.isChecksumForMultiFiles, tt.filename.IsChecksumForMultiFiles())
+		})
+	}
+}
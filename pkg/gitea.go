@@ -0,0 +1,193 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// defaultCodebergURL is URL of codeberg.org.
+const defaultCodebergURL = "https://codeberg.org"
+
+// giteaPageSize is number of items per page in Gitea API.
+const giteaPageSize = 50
+
+// errGiteaNotFound is returned when Gitea API respond 404.
+var errGiteaNotFound = errors.New("resource was not found in Gitea")
+
+// giteaSource is release source backed by Gitea compatible API such as Forgejo or Codeberg.
+type giteaSource struct {
+	url   string
+	token string
+}
+
+// giteaRepository is repository in Gitea API response.
+type giteaRepository struct {
+	Name  string `json:"name"`
+	Owner struct {
+		Login string `json:"login"`
+	} `json:"owner"`
+}
+
+// giteaRelease is release in Gitea API response.
+type giteaRelease struct {
+	TagName string            `json:"tag_name"`
+	Draft   bool              `json:"draft"`
+	Assets  []giteaAttachment `json:"assets"`
+}
+
+// giteaAttachment is release attachment in Gitea API response.
+type giteaAttachment struct {
+	Name               string `json:"name"`
+	BrowserDownloadURL string `json:"browser_download_url"`
+}
+
+// newGiteaSource return new release source backed by Gitea compatible API.
+func newGiteaSource(url string, token string) giteaSource {
+	return giteaSource{
+		url:   url,
+		token: token,
+	}
+}
+
+// SearchRepository search Gitea repository.
+func (s giteaSource) SearchRepository(ctx context.Context, query string) (Repository, error) {
+	result := struct {
+		Data []giteaRepository `json:"data"`
+	}{}
+	params := url.Values{}
+	params.Set("q", query)
+	params.Set("sort", "stars")
+	params.Set("order", "desc")
+	if err := s.get(ctx, "/repos/search", params, &result); err != nil {
+		return Repository{}, err
+	}
+	if len(result.Data) == 0 {
+		return Repository{}, fmt.Errorf("%w by query %s", ErrRepositoryNotFound, query)
+	}
+	return result.Data[0].repository(), nil
+}
+
+// FindRepository find Gitea repository.
+func (s giteaSource) FindRepository(ctx context.Context, owner string, name string) (Repository, error) {
+	repo := giteaRepository{}
+	err := s.get(ctx, giteaRepositoryPath(NewRepository(owner, name)), nil, &repo)
+	if errors.Is(err, errGiteaNotFound) {
+		return Repository{}, fmt.Errorf("%w: %s/%s", ErrRepositoryNotFound, owner, name)
+	}
+	if err != nil {
+		return Repository{}, err
+	}
+	return repo.repository(), nil
+}
+
+// LatestRelease return latest Gitea release.
+func (s giteaSource) LatestRelease(ctx context.Context, repo Repository) (Release, error) {
+	release := giteaRelease{}
+	err := s.get(ctx, giteaRepositoryPath(repo)+"/releases/latest", nil, &release)
+	if errors.Is(err, errGiteaNotFound) {
+		return Release{}, fmt.Errorf("%w in %s/%s", ErrReleaseNotFound, repo.Owner, repo.Name)
+	}
+	if err != nil {
+		return Release{}, err
+	}
+	return NewRelease(release.TagName), nil
+}
+
+// ListReleases list published releases in Gitea repository.
+func (s giteaSource) ListReleases(ctx context.Context, repo Repository) ([]Release, error) {
+	result := []Release{}
+	for page := 1; ; page++ {
+		releases := []giteaRelease{}
+		params := url.Values{}
+		params.Set("page", strconv.Itoa(page))
+		params.Set("limit", strconv.Itoa(giteaPageSize))
+		if err := s.get(ctx, giteaRepositoryPath(repo)+"/releases", params, &releases); err != nil {
+			return result, err
+		}
+		for _, release := range releases {
+			if release.Draft {
+				continue
+			}
+			result = append(result, NewRelease(release.TagName))
+		}
+		if len(releases) < giteaPageSize {
+			return result, nil
+		}
+	}
+}
+
+// FindReleaseByTag return Gitea release by tag.
+func (s giteaSource) FindReleaseByTag(ctx context.Context, repo Repository, tag string) (Release, error) {
+	release, err := s.findRelease(ctx, repo, tag)
+	if err != nil {
+		return Release{}, err
+	}
+	return NewRelease(release.TagName), nil
+}
+
+// ListAssets list attachments in Gitea release as assets.
+func (s giteaSource) ListAssets(ctx context.Context, repo Repository, release Release) ([]Asset, error) {
+	found, err := s.findRelease(ctx, repo, release.Tag)
+	if err != nil {
+		return nil, err
+	}
+	result := []Asset{}
+	for _, attachment := range found.Assets {
+		result = append(result, NewAsset(NewURL(attachment.BrowserDownloadURL)))
+	}
+	return result, nil
+}
+
+// findRelease return Gitea release by tag.
+func (s giteaSource) findRelease(ctx context.Context, repo Repository, tag string) (giteaRelease, error) {
+	release := giteaRelease{}
+	err := s.get(ctx, giteaRepositoryPath(repo)+"/releases/tags/"+url.PathEscape(tag), nil, &release)
+	if errors.Is(err, errGiteaNotFound) {
+		return giteaRelease{}, fmt.Errorf("%w: %s in %s/%s", ErrReleaseNotFound, tag, repo.Owner, repo.Name)
+	}
+	return release, err
+}
+
+// get send GET request to Gitea API and decode JSON response into v.
+func (s giteaSource) get(ctx context.Context, path string, params url.Values, v any) error {
+	endpoint := strings.TrimSuffix(s.url, "/") + "/api/v1" + path
+	if len(params) > 0 {
+		endpoint = endpoint + "?" + params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	if s.token != "" {
+		req.Header.Set("Authorization", "token "+s.token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return errGiteaNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", endpoint, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// repository return Gitea repository as repository.
+func (r giteaRepository) repository() Repository {
+	return NewRepository(r.Owner.Login, r.Name)
+}
+
+// giteaRepositoryPath return path of repository in Gitea API.
+func giteaRepositoryPath(repo Repository) string {
+	return fmt.Sprintf("/repos/%s/%s", url.PathEscape(repo.Owner), url.PathEscape(repo.Name))
+}
@@ -0,0 +1,202 @@
+package pkg
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeGiteaServer return fake Gitea API server which has "shibataka000/hello" repository
+// and "shibataka000/empty" repository which has no release.
+func newFakeGiteaServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "token test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/api/v1/repos/search":
+			_, _ = w.Write([]byte(`{"ok":true,"data":[{"name":"hello","owner":{"login":"shibataka000"}}]}`))
+		case "/api/v1/repos/shibataka000/hello":
+			_, _ = w.Write([]byte(`{"name":"hello","owner":{"login":"shibataka000"}}`))
+		case "/api/v1/repos/shibataka000/hello/releases/latest":
+			_, _ = w.Write([]byte(`{"id":2,"tag_name":"v0.2.0"}`))
+		case "/api/v1/repos/shibataka000/hello/releases":
+			_, _ = w.Write([]byte(`[{"id":3,"tag_name":"v0.3.0","draft":true},{"id":2,"tag_name":"v0.2.0"},{"id":1,"tag_name":"v0.1.0"}]`))
+		case "/api/v1/repos/shibataka000/hello/releases/tags/v0.1.0":
+			_, _ = w.Write([]byte(`{"id":1,"tag_name":"v0.1.0","assets":[` +
+				`{"name":"hello_0.1.0_darwin_arm64.tar.gz","browser_download_url":"https://codeberg.org/shibataka000/hello/releases/download/v0.1.0/hello_0.1.0_darwin_arm64.tar.gz"},` +
+				`{"name":"hello_0.1.0_linux_amd64.tar.gz","browser_download_url":"https://codeberg.org/shibataka000/hello/releases/download/v0.1.0/hello_0.1.0_linux_amd64.tar.gz"}` +
+				`]}`))
+		case "/api/v1/repos/shibataka000/empty":
+			_, _ = w.Write([]byte(`{"name":"empty","owner":{"login":"shibataka000"}}`))
+		case "/api/v1/repos/shibataka000/empty/releases":
+			_, _ = w.Write([]byte(`[]`))
+		case "/shibataka000/hello/releases/download/v0.1.0/hello":
+			_, _ = w.Write([]byte("helloworld\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGiteaSource(t *testing.T) {
+	assert := require.New(t)
+	server := newFakeGiteaServer(t)
+	ctx := context.Background()
+	source := newGiteaSource(server.URL, "test-token")
+	repo := NewRepository("shibataka000", "hello")
+
+	found, err := source.FindRepository(ctx, "shibataka000", "hello")
+	assert.NoError(err)
+	assert.Equal(repo, found)
+
+	_, err = source.FindRepository(ctx, "shibataka000", "unknown")
+	assert.ErrorIs(err, ErrRepositoryNotFound)
+
+	found, err = source.SearchRepository(ctx, "hello")
+	assert.NoError(err)
+	assert.Equal(repo, found)
+
+	latest, err := source.LatestRelease(ctx, repo)
+	assert.NoError(err)
+	assert.Equal(NewRelease("v0.2.0"), latest)
+
+	releases, err := source.ListReleases(ctx, repo)
+	assert.NoError(err)
+	assert.Equal([]Release{NewRelease("v0.2.0"), NewRelease("v0.1.0")}, releases)
+
+	release, err := source.FindReleaseByTag(ctx, repo, "v0.1.0")
+	assert.NoError(err)
+	assert.Equal(NewRelease("v0.1.0"), release)
+
+	assets, err := source.ListAssets(ctx, repo, release)
+	assert.NoError(err)
+	assert.Len(assets, 2)
+}
+
+func TestApplicationServiceSearchGitea(t *testing.T) {
+	assert := require.New(t)
+	server := newFakeGiteaServer(t)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	ctx := context.Background()
+	repository, err := NewInfrastructureRepository(ctx, InfrastructureOptions{Token: os.Getenv("GITHUB_TOKEN"), GiteaURL: server.URL, GiteaToken: "test-token"})
+	assert.NoError(err)
+	app := NewApplicationService(repository, NewFactory(), InstallOptions{})
+
+	query, err := ParseQuery("gitea:shibataka000/hello=v0.1.0")
+	assert.NoError(err)
+	pkg, err := app.Search(ctx, query, NewPlatform("linux", "amd64"))
+	assert.NoError(err)
+	assert.Equal(SourceGitea, pkg.Source)
+	assert.Equal(NewRelease("v0.1.0"), pkg.Release)
+	assert.Equal(NewAsset("https://codeberg.org/shibataka000/hello/releases/download/v0.1.0/hello_0.1.0_linux_amd64.tar.gz"), pkg.Asset)
+	assert.Equal(NewExecBinary("hello"), pkg.ExecBinary)
+}
+
+func TestApplicationServiceSearchGiteaReleaseNotFound(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+	}{
+		{
+			name:  "latest",
+			query: "gitea:shibataka000/empty",
+		},
+		{
+			name:  "constraint",
+			query: "gitea:shibataka000/empty@>=0.1",
+		},
+		{
+			name:  "tag",
+			query: "gitea:shibataka000/hello=v9.9.9",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			server := newFakeGiteaServer(t)
+			t.Setenv("XDG_CACHE_HOME", t.TempDir())
+			ctx := context.Background()
+			repository, err := NewInfrastructureRepository(ctx, InfrastructureOptions{Token: os.Getenv("GITHUB_TOKEN"), GiteaURL: server.URL, GiteaToken: "test-token"})
+			assert.NoError(err)
+			app := NewApplicationService(repository, NewFactory(), InstallOptions{})
+
+			query, err := ParseQuery(tt.query)
+			assert.NoError(err)
+			_, err = app.Search(ctx, query, NewPlatform("linux", "amd64"))
+			assert.ErrorIs(err, ErrReleaseNotFound)
+		})
+	}
+}
+
+func TestInfrastructureRepositoryDownloadGiteaAsset(t *testing.T) {
+	assert := require.New(t)
+	server := newFakeGiteaServer(t)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	ctx := context.Background()
+	repository, err := NewInfrastructureRepository(ctx, InfrastructureOptions{Token: os.Getenv("GITHUB_TOKEN"), GiteaURL: server.URL, GiteaToken: "test-token"})
+	assert.NoError(err)
+
+	file, err := repository.Download(NewURL(server.URL+"/shibataka000/hello/releases/download/v0.1.0/hello"), io.Discard)
+	assert.NoError(err)
+	assert.Equal(NewFile("hello", []byte("helloworld\n")), file)
+}
+
+func TestInfrastructureRepositoryCodebergToken(t *testing.T) {
+	tests := []struct {
+		name     string
+		giteaURL string
+		token    string
+	}{
+		{
+			name:     "default",
+			giteaURL: "",
+			token:    "test-token",
+		},
+		{
+			name:     "codeberg",
+			giteaURL: "https://codeberg.org/",
+			token:    "test-token",
+		},
+		{
+			name:     "self-hosted",
+			giteaURL: "https://git.example.com",
+			token:    "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			repository, err := NewInfrastructureRepository(context.Background(), InfrastructureOptions{GiteaURL: tt.giteaURL, GiteaToken: "test-token"})
+			assert.NoError(err)
+			assert.Equal(tt.token, repository.codebergToken())
+		})
+	}
+}
+
+func TestCachedSourceUnwritableCache(t *testing.T) {
+	assert := require.New(t)
+	server := newFakeGiteaServer(t)
+	repository := NewInfrastructureRepositoryForTest(context.Background(), t)
+	// cache directory can't be created under regular file.
+	file := filepath.Join(t.TempDir(), "file")
+	assert.NoError(os.WriteFile(file, []byte{}, 0644))
+	t.Setenv("XDG_CACHE_HOME", file)
+	source := newCachedSource(repository, newGiteaSource(server.URL, "test-token"), server.URL)
+
+	found, err := source.FindRepository(context.Background(), "shibataka000", "hello")
+	assert.NoError(err)
+	assert.Equal(NewRepository("shibataka000", "hello"), found)
+}
@@ -1,5 +1,11 @@
 package pkg
 
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
 // GitHubRepository is repository in GitHub.
 type GitHubRepository struct {
 	Owner string
@@ -40,29 +46,21 @@ func NewGitHubAsset(downloadURL URL) GitHubAsset {
 	}
 }
 
-// HasExecBinary return true if asset has exec binary.
-func (a GitHubAsset) HasExecBinary() bool {
-	filename := a.DownloadURL.FileName()
-	return filename.IsExecBinary() || filename.IsArchived() || filename.IsCompressed()
-}
-
-// Platform return platform guessed by asset file name.
-func (a GitHubAsset) Platform() (Platform, error) {
-	filename := a.DownloadURL.FileName()
-	return filename.Platform()
-}
-
-// FilterGitHubAssetByPlatform filter assets which has executable binary for specified platform.
-func FilterGitHubAssetByPlatform(assets []GitHubAsset, platform Platform) []GitHubAsset {
-	result := []GitHubAsset{}
-	for _, asset := range assets {
-		p, err := asset.Platform()
-		if err != nil {
-			continue
-		}
-		if asset.HasExecBinary() && platform.Equals(p) {
-			result = append(result, asset)
-		}
+// ParseGitHubReleaseAssetURL parse download URL of GitHub release asset
+// such as "https://github.com/<owner>/<repo>/releases/download/<tag>/<asset>" and return repository, tag and asset file name.
+// host is host of GitHub Enterprise Server. If it is empty, only github.com is accepted.
+func ParseGitHubReleaseAssetURL(downloadURL URL, host string) (GitHubRepository, string, FileName, error) {
+	u, err := url.Parse(downloadURL.String())
+	if err != nil {
+		return GitHubRepository{}, "", "", err
+	}
+	if u.Host != "github.com" && (host == "" || u.Host != host) {
+		return GitHubRepository{}, "", "", fmt.Errorf("%s is not GitHub release asset URL", downloadURL)
+	}
+	parts := strings.Split(strings.TrimPrefix(u.Path, "/"), "/")
+	if len(parts) < 6 || parts[2] != "releases" || parts[3] != "download" {
+		return GitHubRepository{}, "", "", fmt.Errorf("%s is not GitHub release asset URL", downloadURL)
 	}
-	return result
+	tag := strings.Join(parts[4:len(parts)-1], "/")
+	return NewGitHubRepository(parts[0], parts[1]), tag, NewFileName(parts[len(parts)-1]), nil
 }
@@ -6,96 +6,55 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
-func TestGitHubAssetHasExecBinary(t *testing.T) {
+func TestParseGitHubReleaseAssetURL(t *testing.T) {
 	tests := []struct {
-		name          string
-		asset         GitHubAsset
-		hasExecBinary bool
+		name  string
+		url   URL
+		host  string
+		repo  GitHubRepository
+		tag   string
+		asset FileName
+		err   bool
 	}{
 		{
-			name:          "https://github.com/cli/cli/releases/download/v2.21.0/gh_2.21.0_linux_amd64.tar.gz",
-			asset:         NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.0/gh_2.21.0_linux_amd64.tar.gz"),
-			hasExecBinary: true,
+			name:  "github.com",
+			url:   "https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.tar.gz",
+			repo:  NewGitHubRepository("cli", "cli"),
+			tag:   "v2.21.1",
+			asset: "gh_2.21.1_linux_amd64.tar.gz",
 		},
 		{
-			name:          "https://github.com/cli/cli/releases/download/v2.21.0/gh_2.21.0_checksums.txt",
-			asset:         NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.0/gh_2.21.0_checksums.txt"),
-			hasExecBinary: false,
+			name:  "GitHub Enterprise Server",
+			url:   "https://ghe.example.com/owner/tool/releases/download/v1.0.0/tool",
+			host:  "ghe.example.com",
+			repo:  NewGitHubRepository("owner", "tool"),
+			tag:   "v1.0.0",
+			asset: "tool",
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			assert := require.New(t)
-			assert.Equal(tt.hasExecBinary, tt.asset.HasExecBinary())
-		})
-	}
-}
-
-func TestGitHubAssetPlatform(t *testing.T) {
-	tests := []struct {
-		name     string
-		asset    GitHubAsset
-		platform Platform
-	}{
 		{
-			name:     "https://github.com/cli/cli/releases/download/v2.21.0/gh_2.21.0_linux_amd64.tar.gz",
-			asset:    NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.0/gh_2.21.0_linux_amd64.tar.gz"),
-			platform: NewPlatform("linux", "amd64"),
+			name: "unknown host",
+			url:  "https://ghe.example.com/owner/tool/releases/download/v1.0.0/tool",
+			err:  true,
 		},
-	}
-
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			assert := require.New(t)
-			platform, err := tt.asset.Platform()
-			assert.NoError(err)
-			assert.Equal(tt.platform, platform)
-		})
-	}
-}
-
-func TestFilterGitHubAssetByPlatform(t *testing.T) {
-	tests := []struct {
-		name     string
-		assets   []GitHubAsset
-		platform Platform
-		filtered []GitHubAsset
-	}{
 		{
-			name: "cli/cli",
-			assets: []GitHubAsset{
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_checksums.txt"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_386.deb"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_386.rpm"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_386.tar.gz"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.deb"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.rpm"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.tar.gz"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_arm64.deb"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_arm64.rpm"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_arm64.tar.gz"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_armv6.deb"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_armv6.rpm"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_armv6.tar.gz"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_macOS_amd64.tar.gz"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_386.zip"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_amd64.msi"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_amd64.zip"),
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_arm64.zip"),
-			},
-			platform: NewPlatform("linux", "amd64"),
-			filtered: []GitHubAsset{
-				NewGitHubAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.tar.gz"),
-			},
+			name: "not release asset",
+			url:  "https://github.com/cli/cli/archive/refs/tags/v2.21.1.tar.gz",
+			err:  true,
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			assert := require.New(t)
-			filtered := FilterGitHubAssetByPlatform(tt.assets, tt.platform)
-			assert.Equal(tt.filtered, filtered)
+			repo, tag, asset, err := ParseGitHubReleaseAssetURL(tt.url, tt.host)
+			if tt.err {
+				assert.Error(err)
+				return
+			}
+			assert.NoError(err)
+			assert.Equal(tt.repo, repo)
+			assert.Equal(tt.tag, tag)
+			assert.Equal(tt.asset, asset)
 		})
 	}
 }
@@ -0,0 +1,216 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// defaultGitLabURL is URL of gitlab.com.
+const defaultGitLabURL = "https://gitlab.com"
+
+// errGitLabNotFound is returned when GitLab API respond 404.
+var errGitLabNotFound = errors.New("resource was not found in GitLab")
+
+// gitLabSource is release source backed by GitLab API.
+type gitLabSource struct {
+	url   string
+	token string
+}
+
+// gitLabProject is project in GitLab API response.
+type gitLabProject struct {
+	Path      string `json:"path"`
+	Namespace struct {
+		FullPath string `json:"full_path"`
+	} `json:"namespace"`
+}
+
+// gitLabRelease is release in GitLab API response.
+// Upcoming release is release whose release date is in the future.
+type gitLabRelease struct {
+	TagName         string `json:"tag_name"`
+	UpcomingRelease bool   `json:"upcoming_release"`
+	Assets          struct {
+		Links []gitLabLink `json:"links"`
+	} `json:"assets"`
+}
+
+// gitLabLink is release link in GitLab API response.
+type gitLabLink struct {
+	Name           string `json:"name"`
+	URL            string `json:"url"`
+	DirectAssetURL string `json:"direct_asset_url"`
+}
+
+// newGitLabSource return new release source backed by GitLab API.
+func newGitLabSource(url string, token string) gitLabSource {
+	return gitLabSource{
+		url:   url,
+		token: token,
+	}
+}
+
+// SearchRepository search GitLab project.
+func (s gitLabSource) SearchRepository(ctx context.Context, query string) (Repository, error) {
+	projects := []gitLabProject{}
+	params := url.Values{}
+	params.Set("search", query)
+	params.Set("order_by", "star_count")
+	if _, err := s.get(ctx, "/projects", params, &projects); err != nil {
+		return Repository{}, err
+	}
+	if len(projects) == 0 {
+		return Repository{}, fmt.Errorf("%w by query %s", ErrRepositoryNotFound, query)
+	}
+	return projects[0].repository(), nil
+}
+
+// FindRepository find GitLab project.
+// owner is full path of group such as "group/subgroup".
+func (s gitLabSource) FindRepository(ctx context.Context, owner string, name string) (Repository, error) {
+	project := gitLabProject{}
+	_, err := s.get(ctx, "/projects/"+gitLabProjectID(NewRepository(owner, name)), nil, &project)
+	if errors.Is(err, errGitLabNotFound) {
+		return Repository{}, fmt.Errorf("%w: %s/%s", ErrRepositoryNotFound, owner, name)
+	}
+	if err != nil {
+		return Repository{}, err
+	}
+	return project.repository(), nil
+}
+
+// LatestRelease return latest GitLab release.
+// Releases are listed in descending order of release date, so this return first one which is not upcoming release.
+func (s gitLabSource) LatestRelease(ctx context.Context, repo Repository) (Release, error) {
+	for page := 1; page != 0; {
+		releases, next, err := s.listReleases(ctx, repo, page)
+		if err != nil {
+			return Release{}, err
+		}
+		for _, release := range releases {
+			if !release.UpcomingRelease {
+				return NewRelease(release.TagName), nil
+			}
+		}
+		page = next
+	}
+	return Release{}, fmt.Errorf("%w in %s/%s", ErrReleaseNotFound, repo.Owner, repo.Name)
+}
+
+// ListReleases list released releases in GitLab project.
+func (s gitLabSource) ListReleases(ctx context.Context, repo Repository) ([]Release, error) {
+	result := []Release{}
+	for page := 1; page != 0; {
+		releases, next, err := s.listReleases(ctx, repo, page)
+		if err != nil {
+			return result, err
+		}
+		for _, release := range releases {
+			if release.UpcomingRelease {
+				continue
+			}
+			result = append(result, NewRelease(release.TagName))
+		}
+		page = next
+	}
+	return result, nil
+}
+
+// listReleases list releases in page of GitLab project.
+// This return next page number too, or 0 if there is no next page.
+func (s gitLabSource) listReleases(ctx context.Context, repo Repository, page int) ([]gitLabRelease, int, error) {
+	releases := []gitLabRelease{}
+	params := url.Values{}
+	params.Set("page", strconv.Itoa(page))
+	params.Set("per_page", "100")
+	next, err := s.get(ctx, "/projects/"+gitLabProjectID(repo)+"/releases", params, &releases)
+	return releases, next, err
+}
+
+// FindReleaseByTag return GitLab release by tag.
+func (s gitLabSource) FindReleaseByTag(ctx context.Context, repo Repository, tag string) (Release, error) {
+	release, err := s.findRelease(ctx, repo, tag)
+	if err != nil {
+		return Release{}, err
+	}
+	return NewRelease(release.TagName), nil
+}
+
+// ListAssets list release links in GitLab release as assets.
+func (s gitLabSource) ListAssets(ctx context.Context, repo Repository, release Release) ([]Asset, error) {
+	found, err := s.findRelease(ctx, repo, release.Tag)
+	if err != nil {
+		return nil, err
+	}
+	result := []Asset{}
+	for _, link := range found.Assets.Links {
+		downloadURL := link.DirectAssetURL
+		if downloadURL == "" {
+			downloadURL = link.URL
+		}
+		result = append(result, NewAsset(NewURL(downloadURL)))
+	}
+	return result, nil
+}
+
+// findRelease return GitLab release by tag.
+func (s gitLabSource) findRelease(ctx context.Context, repo Repository, tag string) (gitLabRelease, error) {
+	release := gitLabRelease{}
+	_, err := s.get(ctx, "/projects/"+gitLabProjectID(repo)+"/releases/"+url.PathEscape(tag), nil, &release)
+	if errors.Is(err, errGitLabNotFound) {
+		return gitLabRelease{}, fmt.Errorf("%w: %s in %s/%s", ErrReleaseNotFound, tag, repo.Owner, repo.Name)
+	}
+	return release, err
+}
+
+// get send GET request to GitLab API and decode JSON response into v.
+// This return next page number, or 0 if there is no next page.
+func (s gitLabSource) get(ctx context.Context, path string, params url.Values, v any) (int, error) {
+	endpoint := strings.TrimSuffix(s.url, "/") + "/api/v4" + path
+	if len(params) > 0 {
+		endpoint = endpoint + "?" + params.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	if s.token != "" {
+		req.Header.Set("PRIVATE-TOKEN", s.token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return 0, errGitLabNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, fmt.Errorf("GET %s: %s", endpoint, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return 0, err
+	}
+	next, err := strconv.Atoi(resp.Header.Get("X-Next-Page"))
+	if err != nil {
+		return 0, nil
+	}
+	return next, nil
+}
+
+// repository return GitLab project as repository.
+func (p gitLabProject) repository() Repository {
+	return NewRepository(p.Namespace.FullPath, p.Path)
+}
+
+// gitLabProjectID return URL encoded full path of GitLab project which is used as project ID in GitLab API.
+func gitLabProjectID(repo Repository) string {
+	return url.PathEscape(fmt.Sprintf("%s/%s", repo.Owner, repo.Name))
+}
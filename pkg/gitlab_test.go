@@ -0,0 +1,132 @@
+package pkg
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newFakeGitLabServer return fake GitLab API server which has "shibataka000/tools/hello" project.
+func newFakeGitLabServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects":
+			_, _ = w.Write([]byte(`[{"path":"hello","namespace":{"full_path":"shibataka000/tools"}}]`))
+		case "/api/v4/projects/shibataka000%2Ftools%2Fhello":
+			_, _ = w.Write([]byte(`{"path":"hello","namespace":{"full_path":"shibataka000/tools"}}`))
+		case "/api/v4/projects/shibataka000%2Ftools%2Fhello/releases":
+			if r.URL.Query().Get("page") == "1" {
+				w.Header().Set("X-Next-Page", "2")
+			}
+			if r.URL.Query().Get("page") == "2" {
+				_, _ = w.Write([]byte(`[{"tag_name":"v0.1.0"}]`))
+				return
+			}
+			_, _ = w.Write([]byte(`[{"tag_name":"v0.3.0","upcoming_release":true},{"tag_name":"v0.2.0"}]`))
+		case "/api/v4/projects/shibataka000%2Ftools%2Fempty/releases":
+			_, _ = w.Write([]byte(`[{"tag_name":"v0.1.0","upcoming_release":true}]`))
+		case "/api/v4/projects/shibataka000%2Ftools%2Fhello/releases/v0.2.0":
+			_, _ = w.Write([]byte(`{"tag_name":"v0.2.0","assets":{"links":[` +
+				`{"name":"hello_linux_amd64.gz","url":"https://example.com/hello_linux_amd64.gz","direct_asset_url":"https://gitlab.example.com/shibataka000/tools/hello/-/releases/v0.2.0/downloads/hello_linux_amd64.gz"},` +
+				`{"name":"hello_darwin_arm64.gz","url":"https://example.com/hello_darwin_arm64.gz"}` +
+				`]}}`))
+		case "/shibataka000/tools/hello/-/releases/v0.2.0/downloads/hello_linux_amd64.gz":
+			if r.Header.Get("Authorization") != "Bearer test-token" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte("helloworld\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGitLabSource(t *testing.T) {
+	assert := require.New(t)
+	server := newFakeGitLabServer(t)
+	ctx := context.Background()
+	source := newGitLabSource(server.URL, "")
+	repo := NewRepository("shibataka000/tools", "hello")
+
+	found, err := source.FindRepository(ctx, "shibataka000/tools", "hello")
+	assert.NoError(err)
+	assert.Equal(repo, found)
+
+	_, err = source.FindRepository(ctx, "shibataka000", "unknown")
+	assert.ErrorIs(err, ErrRepositoryNotFound)
+
+	found, err = source.SearchRepository(ctx, "hello")
+	assert.NoError(err)
+	assert.Equal(repo, found)
+
+	latest, err := source.LatestRelease(ctx, repo)
+	assert.NoError(err)
+	assert.Equal(NewRelease("v0.2.0"), latest)
+
+	releases, err := source.ListReleases(ctx, repo)
+	assert.NoError(err)
+	assert.Equal([]Release{NewRelease("v0.2.0"), NewRelease("v0.1.0")}, releases)
+
+	release, err := source.FindReleaseByTag(ctx, repo, "v0.2.0")
+	assert.NoError(err)
+	assert.Equal(NewRelease("v0.2.0"), release)
+
+	_, err = source.LatestRelease(ctx, NewRepository("shibataka000/tools", "empty"))
+	assert.ErrorIs(err, ErrReleaseNotFound)
+
+	_, err = source.FindReleaseByTag(ctx, repo, "v9.9.9")
+	assert.ErrorIs(err, ErrReleaseNotFound)
+
+	assets, err := source.ListAssets(ctx, repo, release)
+	assert.NoError(err)
+	assert.Equal([]Asset{
+		NewAsset("https://gitlab.example.com/shibataka000/tools/hello/-/releases/v0.2.0/downloads/hello_linux_amd64.gz"),
+		NewAsset("https://example.com/hello_darwin_arm64.gz"),
+	}, assets)
+}
+
+func TestApplicationServiceSearchGitLab(t *testing.T) {
+	assert := require.New(t)
+	server := newFakeGitLabServer(t)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	ctx := context.Background()
+	// Index describes GitHub repository which has same owner and name. It must not be applied to GitLab project.
+	index := filepath.Join(t.TempDir(), "index.yaml")
+	assert.NoError(os.WriteFile(index, []byte("- owner: shibataka000/tools\n  repo: hello\n  execBinary:\n    name: github-hello\n"), 0644))
+	repository, err := NewInfrastructureRepository(ctx, InfrastructureOptions{Token: os.Getenv("GITHUB_TOKEN"), GitLabURL: server.URL, IndexPaths: []string{index}})
+	assert.NoError(err)
+	app := NewApplicationService(repository, NewFactory(), InstallOptions{})
+
+	query, err := ParseQuery("gitlab:shibataka000/tools/hello@>=0.1")
+	assert.NoError(err)
+	pkg, err := app.Search(ctx, query, NewPlatform("linux", "amd64"))
+	assert.NoError(err)
+	assert.Equal(SourceGitLab, pkg.Source)
+	assert.Equal(NewRepository("shibataka000/tools", "hello"), pkg.Repository)
+	assert.Equal(NewRelease("v0.2.0"), pkg.Release)
+	assert.Equal(NewAsset("https://gitlab.example.com/shibataka000/tools/hello/-/releases/v0.2.0/downloads/hello_linux_amd64.gz"), pkg.Asset)
+	assert.Equal(NewExecBinary("hello"), pkg.ExecBinary)
+}
+
+func TestInfrastructureRepositoryDownloadGitLabAsset(t *testing.T) {
+	assert := require.New(t)
+	server := newFakeGitLabServer(t)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	ctx := context.Background()
+	repository, err := NewInfrastructureRepository(ctx, InfrastructureOptions{Token: os.Getenv("GITHUB_TOKEN"), GitLabURL: server.URL, GitLabToken: "test-token"})
+	assert.NoError(err)
+
+	file, err := repository.Download(NewURL(server.URL+"/shibataka000/tools/hello/-/releases/v0.2.0/downloads/hello_linux_amd64.gz"), io.Discard)
+	assert.NoError(err)
+	assert.Equal(NewFile("hello_linux_amd64.gz", []byte("helloworld\n")), file)
+}
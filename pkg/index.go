@@ -10,11 +10,15 @@ type Index struct {
 }
 
 // RepositoryInIndex is repository metadata in index.
+// If asset has multiple executable binaries, all of them are listed in ExecBinaries.
+// Extras are files bundled with executable binary in asset which are installed together.
 type RepositoryInIndex struct {
-	Owner      string            `yaml:"owner"`
-	Name       string            `yaml:"repo"`
-	Assets     []AssetInIndex    `yaml:"assets"`
-	ExecBinary ExecBinaryInIndex `yaml:"execBinary"`
+	Owner        string              `yaml:"owner"`
+	Name         string              `yaml:"repo"`
+	Assets       []AssetInIndex      `yaml:"assets"`
+	ExecBinary   ExecBinaryInIndex   `yaml:"execBinary"`
+	ExecBinaries []ExecBinaryInIndex `yaml:"execBinaries"`
+	Extras       []Extra             `yaml:"extras"`
 }
 
 // AssetInIndex is asset metadata in index.
@@ -22,11 +26,17 @@ type AssetInIndex struct {
 	DownloadURL URLTemplate `yaml:"downloadURL"`
 	OS          string      `yaml:"os"`
 	Arch        string      `yaml:"arch"`
+	ChecksumURL URLTemplate `yaml:"checksumURL"`
 }
 
 // ExecBinaryInIndex is executable binary metadata in index.
+// BaseName is name of installed executable binary.
+// If Path or Regexp is set, executable binary is found in archive by glob or regular expression and renamed to BaseName on install.
+// They are templates rendered with release and platform, e.g. "tool_{{.SemVer}}_{{.OS}}_{{.Arch}}".
 type ExecBinaryInIndex struct {
 	BaseName FileName `yaml:"name"`
+	Path     string   `yaml:"path"`
+	Regexp   string   `yaml:"regexp"`
 }
 
 // NewIndex return new index instance.
@@ -47,11 +57,12 @@ func NewRepositoryInIndex(owner string, name string, assets []AssetInIndex, exec
 }
 
 // NewAssetInIndex return new asset metadata instance in index.
-func NewAssetInIndex(downloadURL URLTemplate, os string, arch string) AssetInIndex {
+func NewAssetInIndex(downloadURL URLTemplate, os string, arch string, checksumURL URLTemplate) AssetInIndex {
 	return AssetInIndex{
 		DownloadURL: downloadURL,
 		OS:          os,
 		Arch:        arch,
+		ChecksumURL: checksumURL,
 	}
 }
 
@@ -62,6 +73,28 @@ func NewExecBinaryInIndex(baseName FileName) ExecBinaryInIndex {
 	}
 }
 
+// NewExecBinaryInIndexWithPattern return new executable binary metadata instance in index which is found in archive by glob or regular expression.
+func NewExecBinaryInIndexWithPattern(baseName FileName, path string, regexp string) ExecBinaryInIndex {
+	return ExecBinaryInIndex{
+		BaseName: baseName,
+		Path:     path,
+		Regexp:   regexp,
+	}
+}
+
+// Merge return new index which has repositories in both indexes.
+// If both indexes have same repository, one in other index takes precedence.
+func (i Index) Merge(other Index) Index {
+	repos := []RepositoryInIndex{}
+	for _, r := range i.Repositories {
+		if _, err := other.FindRepository(NewRepository(r.Owner, r.Name)); err != nil {
+			repos = append(repos, r)
+		}
+	}
+	repos = append(repos, other.Repositories...)
+	return NewIndex(repos)
+}
+
 // FindRepository find repository metadata from index.
 func (i Index) FindRepository(repo Repository) (RepositoryInIndex, error) {
 	for _, r := range i.Repositories {
@@ -88,12 +121,44 @@ func (i Index) HasAsset(repo Repository, platform Platform) bool {
 }
 
 // FindExecBianry find executable binary metadata from index.
+// If multiple executable binaries are defined, first one is returned.
 func (i Index) FindExecBinary(repo Repository) (ExecBinaryInIndex, error) {
-	r, err := i.FindRepository(repo)
+	execBinaries, err := i.FindExecBinaries(repo)
 	if err != nil {
 		return ExecBinaryInIndex{}, err
 	}
-	return r.ExecBinary, nil
+	if len(execBinaries) == 0 {
+		return ExecBinaryInIndex{}, nil
+	}
+	return execBinaries[0], nil
+}
+
+// FindExecBinaries find all executable binary metadata from index.
+func (i Index) FindExecBinaries(repo Repository) ([]ExecBinaryInIndex, error) {
+	r, err := i.FindRepository(repo)
+	if err != nil {
+		return nil, err
+	}
+	execBinaries := []ExecBinaryInIndex{}
+	if !r.ExecBinary.IsEmpty() {
+		execBinaries = append(execBinaries, r.ExecBinary)
+	}
+	for _, execBinary := range r.ExecBinaries {
+		if !execBinary.IsEmpty() {
+			execBinaries = append(execBinaries, execBinary)
+		}
+	}
+	return execBinaries, nil
+}
+
+// FindExtras find extra files declared in index.
+// If repository is not found in index, this return nothing.
+func (i Index) FindExtras(repo Repository) []Extra {
+	r, err := i.FindRepository(repo)
+	if err != nil {
+		return nil
+	}
+	return r.Extras
 }
 
 // HasExecBinary return true if index has executable binary metadata about speficied repository.
@@ -121,7 +186,17 @@ func (r RepositoryInIndex) FindAsset(platform Platform) (AssetInIndex, error) {
 	return AssetInIndex{}, fmt.Errorf("asset for platform %v was not found in index", platform)
 }
 
+// HasChecksum return true if checksum file of asset is defined.
+func (a AssetInIndex) HasChecksum() bool {
+	return a.ChecksumURL != ""
+}
+
 // IsEmpty return true if executable binary metadata is not defined.
 func (b ExecBinaryInIndex) IsEmpty() bool {
 	return b.BaseName == ""
 }
+
+// HasPattern return true if executable binary is found in archive by glob or regular expression.
+func (b ExecBinaryInIndex) HasPattern() bool {
+	return b.Path != "" || b.Regexp != ""
+}
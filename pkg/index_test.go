@@ -22,6 +22,39 @@ func LoadIndexForTest(t *testing.T) (Index, error) {
 	return NewIndex(repos), nil
 }
 
+func TestIndexMerge(t *testing.T) {
+	tests := []struct {
+		name   string
+		index  Index
+		other  Index
+		merged Index
+	}{
+		{
+			name: "override",
+			index: NewIndex([]RepositoryInIndex{
+				NewRepositoryInIndex("argoproj", "argo-cd", nil, NewExecBinaryInIndex("argocd")),
+				NewRepositoryInIndex("hashicorp", "terraform", nil, NewExecBinaryInIndex("terraform")),
+			}),
+			other: NewIndex([]RepositoryInIndex{
+				NewRepositoryInIndex("hashicorp", "terraform", nil, NewExecBinaryInIndex("tf")),
+				NewRepositoryInIndex("shibataka000", "internal-tool", nil, NewExecBinaryInIndex("tool")),
+			}),
+			merged: NewIndex([]RepositoryInIndex{
+				NewRepositoryInIndex("argoproj", "argo-cd", nil, NewExecBinaryInIndex("argocd")),
+				NewRepositoryInIndex("hashicorp", "terraform", nil, NewExecBinaryInIndex("tf")),
+				NewRepositoryInIndex("shibataka000", "internal-tool", nil, NewExecBinaryInIndex("tool")),
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.ElementsMatch(tt.merged.Repositories, tt.index.Merge(tt.other).Repositories)
+		})
+	}
+}
+
 func TestIndexFindRepository(t *testing.T) {
 	tests := []struct {
 		name       string
@@ -32,9 +65,9 @@ func TestIndexFindRepository(t *testing.T) {
 			name:       "hashicorp/terraform",
 			githubRepo: NewRepository("hashicorp", "terraform"),
 			indexRepo: NewRepositoryInIndex("hashicorp", "terraform", []AssetInIndex{
-				NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_linux_amd64.zip", "linux", "amd64"),
-				NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_darwin_amd64.zip", "darwin", "amd64"),
-				NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_windows_amd64.zip", "windows", "amd64"),
+				NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_linux_amd64.zip", "linux", "amd64", "https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_SHA256SUMS"),
+				NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_darwin_amd64.zip", "darwin", "amd64", "https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_SHA256SUMS"),
+				NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_windows_amd64.zip", "windows", "amd64", "https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_SHA256SUMS"),
 			}, NewExecBinaryInIndex("terraform")),
 		},
 	}
@@ -62,7 +95,7 @@ func TestIndexFindAsset(t *testing.T) {
 			name:       "hashicorp/terraform",
 			repository: NewRepository("hashicorp", "terraform"),
 			platform:   NewPlatform("linux", "amd64"),
-			asset:      NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_linux_amd64.zip", "linux", "amd64"),
+			asset:      NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_linux_amd64.zip", "linux", "amd64", "https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_SHA256SUMS"),
 		},
 	}
 
@@ -135,6 +168,53 @@ func TestIndexFindExecBinary(t *testing.T) {
 	}
 }
 
+func TestIndexFindExecBinaries(t *testing.T) {
+	tests := []struct {
+		name         string
+		indexRepo    RepositoryInIndex
+		execBinaries []ExecBinaryInIndex
+	}{
+		{
+			name:         "execBinary",
+			indexRepo:    NewRepositoryInIndex("protocolbuffers", "protobuf", nil, NewExecBinaryInIndex("protoc")),
+			execBinaries: []ExecBinaryInIndex{NewExecBinaryInIndex("protoc")},
+		},
+		{
+			name: "execBinary and execBinaries",
+			indexRepo: RepositoryInIndex{
+				Owner:        "protocolbuffers",
+				Name:         "protobuf",
+				ExecBinary:   NewExecBinaryInIndex("protoc"),
+				ExecBinaries: []ExecBinaryInIndex{NewExecBinaryInIndex("protoc-gen-upb")},
+			},
+			execBinaries: []ExecBinaryInIndex{NewExecBinaryInIndex("protoc"), NewExecBinaryInIndex("protoc-gen-upb")},
+		},
+		{
+			name: "execBinaries only",
+			indexRepo: RepositoryInIndex{
+				Owner:        "protocolbuffers",
+				Name:         "protobuf",
+				ExecBinaries: []ExecBinaryInIndex{NewExecBinaryInIndex("protoc"), NewExecBinaryInIndex("protoc-gen-upb")},
+			},
+			execBinaries: []ExecBinaryInIndex{NewExecBinaryInIndex("protoc"), NewExecBinaryInIndex("protoc-gen-upb")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			index := NewIndex([]RepositoryInIndex{tt.indexRepo})
+			repo := NewRepository("protocolbuffers", "protobuf")
+			execBinaries, err := index.FindExecBinaries(repo)
+			assert.NoError(err)
+			assert.Equal(tt.execBinaries, execBinaries)
+			execBinary, err := index.FindExecBinary(repo)
+			assert.NoError(err)
+			assert.Equal(tt.execBinaries[0], execBinary)
+		})
+	}
+}
+
 func TestIndexHasExecBinary(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -204,12 +284,12 @@ func TestRepositoryInIndexFindAsset(t *testing.T) {
 		{
 			name: "hashicorp/terraform",
 			repository: NewRepositoryInIndex("hashicorp", "terraform", []AssetInIndex{
-				NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_linux_amd64.zip", "linux", "amd64"),
-				NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_darwin_amd64.zip", "darwin", "amd64"),
-				NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_windows_amd64.zip", "windows", "amd64"),
+				NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_linux_amd64.zip", "linux", "amd64", "https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_SHA256SUMS"),
+				NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_darwin_amd64.zip", "darwin", "amd64", "https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_SHA256SUMS"),
+				NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_windows_amd64.zip", "windows", "amd64", "https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_SHA256SUMS"),
 			}, NewExecBinaryInIndex("terraform")),
 			platform: NewPlatform("linux", "amd64"),
-			asset:    NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_linux_amd64.zip", "linux", "amd64"),
+			asset:    NewAssetInIndex("https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_linux_amd64.zip", "linux", "amd64", "https://releases.hashicorp.com/terraform/{{.SemVer}}/terraform_{{.SemVer}}_SHA256SUMS"),
 		},
 	}
 
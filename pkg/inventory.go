@@ -0,0 +1,187 @@
+package pkg
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// Inventory is database of packages installed by this application.
+type Inventory struct {
+	Packages map[string]PackageInInventory
+}
+
+// PackageInInventory is installed package metadata in inventory.
+type PackageInInventory struct {
+	Source      Source           `json:"source,omitempty"`
+	Owner       string           `json:"owner"`
+	Name        string           `json:"repo"`
+	Tag         string           `json:"tag"`
+	Constraint  Constraint       `json:"constraint,omitempty"`
+	DownloadURL URL              `json:"downloadURL"`
+	ExecBinary  FileName         `json:"binary"`
+	OS          string           `json:"os,omitempty"`
+	Arch        string           `json:"arch,omitempty"`
+	Path        string           `json:"-"`
+	SHA256      Digest           `json:"sha256"`
+	InstalledAt time.Time        `json:"installedAt"`
+	Extras      []InstalledExtra `json:"extras,omitempty"`
+}
+
+// NewInventory return new inventory instance.
+// pkgs is keyed by path of installed executable binary.
+func NewInventory(pkgs map[string]PackageInInventory) Inventory {
+	for path, pkg := range pkgs {
+		pkg.Path = path
+		pkgs[path] = pkg
+	}
+	return Inventory{
+		Packages: pkgs,
+	}
+}
+
+// NewPackageInInventory return new installed package metadata instance in inventory.
+func NewPackageInInventory(owner string, name string, tag string, constraint Constraint, downloadURL URL, execBinary FileName, platform Platform, path string, sha256 Digest, installedAt time.Time) PackageInInventory {
+	return PackageInInventory{
+		Owner:       owner,
+		Name:        name,
+		Tag:         tag,
+		Constraint:  constraint,
+		DownloadURL: downloadURL,
+		ExecBinary:  execBinary,
+		OS:          platform.OS,
+		Arch:        platform.Arch,
+		Path:        path,
+		SHA256:      sha256,
+		InstalledAt: installedAt,
+	}
+}
+
+// NewPackageInInventoryFromInstallation return new installed package metadata instance in inventory which records installed package.
+func NewPackageInInventoryFromInstallation(installation Installation) PackageInInventory {
+	pkg := installation.Package
+	installed := NewPackageInInventory(pkg.Repository.Owner, pkg.Repository.Name, pkg.Release.Tag, pkg.Constraint, pkg.Asset.DownloadURL, pkg.ExecBinary.Name, pkg.Platform, installation.Path, installation.SHA256, installation.InstalledAt)
+	installed.Source = pkg.Source
+	installed.Extras = installation.Extras
+	return installed
+}
+
+// Add installed package to inventory.
+// If package is already installed at same path, it is overwritten.
+func (i Inventory) Add(installation Installation) Inventory {
+	pkgs := map[string]PackageInInventory{}
+	for path, pkg := range i.Packages {
+		pkgs[path] = pkg
+	}
+	pkgs[installation.Path] = NewPackageInInventoryFromInstallation(installation)
+	return NewInventory(pkgs)
+}
+
+// Remove installed package at path from inventory.
+func (i Inventory) Remove(path string) Inventory {
+	pkgs := map[string]PackageInInventory{}
+	for p, pkg := range i.Packages {
+		if p != path {
+			pkgs[p] = pkg
+		}
+	}
+	return NewInventory(pkgs)
+}
+
+// HasExtra return true if any installed package has extra file at path.
+func (i Inventory) HasExtra(path string) bool {
+	for _, pkg := range i.Packages {
+		for _, extra := range pkg.Extras {
+			if extra.Path == path {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// List return installed packages sorted by path of executable binary.
+func (i Inventory) List() []PackageInInventory {
+	pkgs := []PackageInInventory{}
+	for _, pkg := range i.Packages {
+		pkgs = append(pkgs, pkg)
+	}
+	sort.Slice(pkgs, func(a, b int) bool { return pkgs[a].Path < pkgs[b].Path })
+	return pkgs
+}
+
+// Find return installed packages which match any of names.
+// Name is executable binary name, repository name or "<owner>/<repo>".
+// If names are empty, all installed packages are returned.
+func (i Inventory) Find(names []string) ([]PackageInInventory, error) {
+	pkgs := i.List()
+	if len(names) == 0 {
+		return pkgs, nil
+	}
+	found := []PackageInInventory{}
+	for _, name := range names {
+		matched := false
+		for _, pkg := range pkgs {
+			if pkg.Matches(name) {
+				found = append(found, pkg)
+				matched = true
+			}
+		}
+		if !matched {
+			return nil, fmt.Errorf("%s is not installed by go-get-release", name)
+		}
+	}
+	return found, nil
+}
+
+// Matches return true if name specifies this package.
+// Name is executable binary name, repository name or "<owner>/<repo>".
+func (p PackageInInventory) Matches(name string) bool {
+	candidates := []string{
+		p.ExecBinary.String(),
+		p.ExecBinary.TrimExecExt().String(),
+		p.Name,
+		fmt.Sprintf("%s/%s", p.Owner, p.Name),
+	}
+	return slices.Contains(candidates, name)
+}
+
+// RepositoryName return "<owner>/<repo>" of installed package.
+// If package was downloaded from URL directly, URL is returned instead.
+func (p PackageInInventory) RepositoryName() string {
+	if p.Source == SourceURL {
+		return p.DownloadURL.String()
+	}
+	return fmt.Sprintf("%s/%s", p.Owner, p.Name)
+}
+
+// Query return query to search latest release of installed package.
+// Constraint which was used to install package is also used to search.
+func (p PackageInInventory) Query() Query {
+	query := NewQuery(NewRepository(p.Owner, p.Name), "")
+	if p.Source == SourceURL {
+		query = NewQueryFromURL(p.DownloadURL)
+	}
+	query.Source = p.Source
+	query.Constraint = p.Constraint
+	query.ExecBinary = p.ExecBinary.TrimExecExt()
+	query.Extras = len(p.Extras) > 0
+	return query
+}
+
+// Platform return platform which package was installed for.
+// If it was not recorded, default platform is returned.
+func (p PackageInInventory) Platform(defaultPlatform Platform) Platform {
+	if p.OS == "" || p.Arch == "" {
+		return defaultPlatform
+	}
+	return NewPlatform(p.OS, p.Arch)
+}
+
+// Dir return directory where executable binary is installed.
+func (p PackageInInventory) Dir() string {
+	return filepath.Dir(p.Path)
+}
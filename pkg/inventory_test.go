@@ -0,0 +1,244 @@
+package pkg
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInventoryAdd(t *testing.T) {
+	installedAt := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	pkg := New(
+		NewRepository("hashicorp", "terraform"),
+		NewRelease("v1.5.0"),
+		NewAsset("https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_linux_amd64.zip"),
+		NewExecBinary("terraform"),
+		Checksum{},
+	)
+	pkg.Platform = NewPlatform("linux", "amd64")
+	terraform := NewInstallation(
+		pkg,
+		"/usr/local/bin/terraform",
+		"8cd07f3a5ff98f2a78cfc366c13fb123eb8d29c1ca37c79df190425d5b9e424d",
+		installedAt,
+	)
+
+	tests := []struct {
+		name         string
+		inventory    Inventory
+		installation Installation
+		added        Inventory
+	}{
+		{
+			name:         "new",
+			inventory:    NewInventory(map[string]PackageInInventory{}),
+			installation: terraform,
+			added: NewInventory(map[string]PackageInInventory{
+				"/usr/local/bin/terraform": NewPackageInInventory("hashicorp", "terraform", "v1.5.0", "", "https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_linux_amd64.zip", "terraform", NewPlatform("linux", "amd64"), "/usr/local/bin/terraform", "8cd07f3a5ff98f2a78cfc366c13fb123eb8d29c1ca37c79df190425d5b9e424d", installedAt),
+			}),
+		},
+		{
+			name: "overwrite",
+			inventory: NewInventory(map[string]PackageInInventory{
+				"/usr/local/bin/terraform": NewPackageInInventory("hashicorp", "terraform", "v1.4.0", "", "https://releases.hashicorp.com/terraform/1.4.0/terraform_1.4.0_linux_amd64.zip", "terraform", NewPlatform("linux", "amd64"), "/usr/local/bin/terraform", "0000000000000000000000000000000000000000000000000000000000000000", installedAt.Add(-time.Hour)),
+			}),
+			installation: terraform,
+			added: NewInventory(map[string]PackageInInventory{
+				"/usr/local/bin/terraform": NewPackageInInventory("hashicorp", "terraform", "v1.5.0", "", "https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_linux_amd64.zip", "terraform", NewPlatform("linux", "amd64"), "/usr/local/bin/terraform", "8cd07f3a5ff98f2a78cfc366c13fb123eb8d29c1ca37c79df190425d5b9e424d", installedAt),
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.added, tt.inventory.Add(tt.installation))
+		})
+	}
+}
+
+func TestInventoryRemove(t *testing.T) {
+	installedAt := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	gh := NewPackageInInventory("cli", "cli", "v2.21.1", "", "https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.tar.gz", "gh", NewPlatform("linux", "amd64"), "/usr/local/bin/gh", "", installedAt)
+	terraform := NewPackageInInventory("hashicorp", "terraform", "v1.5.0", "", "https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_linux_amd64.zip", "terraform", NewPlatform("linux", "amd64"), "/usr/local/bin/terraform", "", installedAt)
+
+	tests := []struct {
+		name      string
+		inventory Inventory
+		path      string
+		removed   Inventory
+	}{
+		{
+			name: "installed",
+			inventory: NewInventory(map[string]PackageInInventory{
+				"/usr/local/bin/terraform": terraform,
+				"/usr/local/bin/gh":        gh,
+			}),
+			path: "/usr/local/bin/gh",
+			removed: NewInventory(map[string]PackageInInventory{
+				"/usr/local/bin/terraform": terraform,
+			}),
+		},
+		{
+			name: "not installed",
+			inventory: NewInventory(map[string]PackageInInventory{
+				"/usr/local/bin/terraform": terraform,
+			}),
+			path: "/usr/local/bin/gh",
+			removed: NewInventory(map[string]PackageInInventory{
+				"/usr/local/bin/terraform": terraform,
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.removed, tt.inventory.Remove(tt.path))
+		})
+	}
+}
+
+func TestInventoryList(t *testing.T) {
+	installedAt := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	gh := NewPackageInInventory("cli", "cli", "v2.21.1", "", "https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.tar.gz", "gh", NewPlatform("linux", "amd64"), "/usr/local/bin/gh", "", installedAt)
+	terraform := NewPackageInInventory("hashicorp", "terraform", "v1.5.0", "", "https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_linux_amd64.zip", "terraform", NewPlatform("linux", "amd64"), "/usr/local/bin/terraform", "", installedAt)
+
+	tests := []struct {
+		name      string
+		inventory Inventory
+		pkgs      []PackageInInventory
+	}{
+		{
+			name: "sorted by path",
+			inventory: NewInventory(map[string]PackageInInventory{
+				"/usr/local/bin/terraform": terraform,
+				"/usr/local/bin/gh":        gh,
+			}),
+			pkgs: []PackageInInventory{gh, terraform},
+		},
+		{
+			name:      "empty",
+			inventory: NewInventory(map[string]PackageInInventory{}),
+			pkgs:      []PackageInInventory{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.pkgs, tt.inventory.List())
+		})
+	}
+}
+
+func TestInventoryFind(t *testing.T) {
+	installedAt := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+	gh := NewPackageInInventory("cli", "cli", "v2.21.1", "", "https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_amd64.zip", "gh.exe", NewPlatform("windows", "amd64"), "C:/bin/gh.exe", "", installedAt)
+	terraform := NewPackageInInventory("hashicorp", "terraform", "v1.5.0", "", "https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_windows_amd64.zip", "terraform.exe", NewPlatform("windows", "amd64"), "C:/bin/terraform.exe", "", installedAt)
+	inventory := NewInventory(map[string]PackageInInventory{
+		"C:/bin/gh.exe":        gh,
+		"C:/bin/terraform.exe": terraform,
+	})
+
+	tests := []struct {
+		name  string
+		names []string
+		pkgs  []PackageInInventory
+		err   error
+	}{
+		{
+			name:  "all",
+			names: []string{},
+			pkgs:  []PackageInInventory{gh, terraform},
+		},
+		{
+			name:  "binary name",
+			names: []string{"gh"},
+			pkgs:  []PackageInInventory{gh},
+		},
+		{
+			name:  "owner/repo",
+			names: []string{"hashicorp/terraform", "gh.exe"},
+			pkgs:  []PackageInInventory{terraform, gh},
+		},
+		{
+			name:  "not installed",
+			names: []string{"kubectl"},
+			err:   fmt.Errorf("kubectl is not installed by go-get-release"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			pkgs, err := inventory.Find(tt.names)
+			if tt.err == nil {
+				assert.NoError(err)
+				assert.Equal(tt.pkgs, pkgs)
+			} else {
+				assert.EqualError(err, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestPackageInInventoryQuery(t *testing.T) {
+	installedAt := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		pkg   PackageInInventory
+		query Query
+	}{
+		{
+			name: "cli/cli",
+			pkg:  NewPackageInInventory("cli", "cli", "v2.21.1", "^2.0", "https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_amd64.zip", "gh.exe", NewPlatform("windows", "amd64"), "C:/bin/gh.exe", "", installedAt),
+			query: Query{
+				Repository: NewRepository("cli", "cli"),
+				Constraint: "^2.0",
+				ExecBinary: "gh",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.query, tt.pkg.Query())
+		})
+	}
+}
+
+func TestPackageInInventoryPlatform(t *testing.T) {
+	installedAt := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name            string
+		pkg             PackageInInventory
+		defaultPlatform Platform
+		platform        Platform
+	}{
+		{
+			name:            "recorded",
+			pkg:             NewPackageInInventory("cli", "cli", "v2.21.1", "", "", "gh", NewPlatform("linux", "arm64"), "/usr/local/bin/gh", "", installedAt),
+			defaultPlatform: NewPlatform("linux", "amd64"),
+			platform:        NewPlatform("linux", "arm64"),
+		},
+		{
+			name:            "not recorded",
+			pkg:             NewPackageInInventory("cli", "cli", "v2.21.1", "", "", "gh", Platform{}, "/usr/local/bin/gh", "", installedAt),
+			defaultPlatform: NewPlatform("linux", "amd64"),
+			platform:        NewPlatform("linux", "amd64"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.platform, tt.pkg.Platform(tt.defaultPlatform))
+		})
+	}
+}
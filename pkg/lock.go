@@ -0,0 +1,78 @@
+package pkg
+
+// Lock is lock file which records resolved packages.
+type Lock struct {
+	Packages []PackageInLock
+}
+
+// PackageInLock is resolved package metadata in lock file.
+// ExecBinaryPattern is rendered pattern to find executable binary in archive, if it is defined in index.
+// Extras is true if extra files were installed, and DeclaredExtras are extra files declared in index.
+type PackageInLock struct {
+	Source            Source            `yaml:"source,omitempty"`
+	Owner             string            `yaml:"owner"`
+	Name              string            `yaml:"repo"`
+	Tag               string            `yaml:"tag"`
+	Constraint        Constraint        `yaml:"constraint,omitempty"`
+	DownloadURL       URL               `yaml:"downloadURL"`
+	ExecBinary        FileName          `yaml:"binary"`
+	ExecBinaryPattern ExecBinaryPattern `yaml:"binaryPattern,omitempty"`
+	OS                string            `yaml:"os,omitempty"`
+	Arch              string            `yaml:"arch,omitempty"`
+	Extras            bool              `yaml:"extras,omitempty"`
+	DeclaredExtras    []Extra           `yaml:"declaredExtras,omitempty"`
+	SHA256            Digest            `yaml:"sha256"`
+}
+
+// NewLock return new lock instance.
+func NewLock(pkgs []PackageInLock) Lock {
+	return Lock{
+		Packages: pkgs,
+	}
+}
+
+// NewLockFromInstallations return new lock instance which records installed packages.
+func NewLockFromInstallations(installations []Installation) Lock {
+	pkgs := []PackageInLock{}
+	for _, installation := range installations {
+		pkgs = append(pkgs, NewPackageInLockFromInstallation(installation))
+	}
+	return NewLock(pkgs)
+}
+
+// NewPackageInLock return new package metadata instance in lock file.
+func NewPackageInLock(owner string, name string, tag string, downloadURL URL, execBinary FileName, sha256 Digest) PackageInLock {
+	return PackageInLock{
+		Owner:       owner,
+		Name:        name,
+		Tag:         tag,
+		DownloadURL: downloadURL,
+		ExecBinary:  execBinary,
+		SHA256:      sha256,
+	}
+}
+
+// NewPackageInLockFromInstallation return new package metadata instance in lock file which records installed package.
+func NewPackageInLockFromInstallation(installation Installation) PackageInLock {
+	pkg := installation.Package
+	locked := NewPackageInLock(pkg.Repository.Owner, pkg.Repository.Name, pkg.Release.Tag, pkg.Asset.DownloadURL, pkg.ExecBinary.Name, installation.SHA256)
+	locked.Source = pkg.Source
+	locked.Constraint = pkg.Constraint
+	locked.ExecBinaryPattern = pkg.ExecBinary.Pattern
+	locked.OS = pkg.Platform.OS
+	locked.Arch = pkg.Platform.Arch
+	locked.Extras = pkg.Extras.Detect
+	locked.DeclaredExtras = pkg.Extras.Declared
+	return locked
+}
+
+// Package return package recorded in lock file.
+// Source, platform, constraint and extra files are restored so that package is installed and recorded as it was resolved.
+func (p PackageInLock) Package() Package {
+	pkg := New(NewRepository(p.Owner, p.Name), NewRelease(p.Tag), NewAsset(p.DownloadURL), NewExecBinaryWithPattern(p.ExecBinary, p.ExecBinaryPattern), Checksum{})
+	pkg.Source = p.Source
+	pkg.Constraint = p.Constraint
+	pkg.Platform = NewPlatform(p.OS, p.Arch)
+	pkg.Extras = NewExtras(p.Extras, p.DeclaredExtras)
+	return pkg
+}
@@ -0,0 +1,112 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewLockFromInstallations(t *testing.T) {
+	tests := []struct {
+		name          string
+		installations []Installation
+		lock          Lock
+	}{
+		{
+			name: "hashicorp/terraform",
+			installations: []Installation{
+				NewInstallation(
+					New(
+						NewRepository("hashicorp", "terraform"),
+						NewRelease("v1.5.0"),
+						NewAsset("https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_linux_amd64.zip"),
+						NewExecBinary("terraform"),
+						NewChecksum("https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_SHA256SUMS"),
+					),
+					"/usr/local/bin/terraform",
+					"8cd07f3a5ff98f2a78cfc366c13fb123eb8d29c1ca37c79df190425d5b9e424d",
+					time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+				),
+			},
+			lock: NewLock([]PackageInLock{
+				NewPackageInLock("hashicorp", "terraform", "v1.5.0", "https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_linux_amd64.zip", "terraform", "8cd07f3a5ff98f2a78cfc366c13fb123eb8d29c1ca37c79df190425d5b9e424d"),
+			}),
+		},
+		{
+			name: "gitlab-org/cli/glab",
+			installations: []Installation{
+				NewInstallation(
+					newGitLabPackageForLockTest(),
+					"/usr/local/bin/glab",
+					"8cd07f3a5ff98f2a78cfc366c13fb123eb8d29c1ca37c79df190425d5b9e424d",
+					time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC),
+				),
+			},
+			lock: NewLock([]PackageInLock{
+				newGitLabPackageInLockForTest(),
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.lock, NewLockFromInstallations(tt.installations))
+		})
+	}
+}
+
+func TestPackageInLockPackage(t *testing.T) {
+	tests := []struct {
+		name   string
+		locked PackageInLock
+		pkg    Package
+	}{
+		{
+			name:   "hashicorp/terraform",
+			locked: NewPackageInLock("hashicorp", "terraform", "v1.5.0", "https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_linux_amd64.zip", "terraform", "8cd07f3a5ff98f2a78cfc366c13fb123eb8d29c1ca37c79df190425d5b9e424d"),
+			pkg: New(
+				NewRepository("hashicorp", "terraform"),
+				NewRelease("v1.5.0"),
+				NewAsset("https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_linux_amd64.zip"),
+				NewExecBinary("terraform"),
+				Checksum{},
+			),
+		},
+		{
+			name:   "gitlab-org/cli/glab",
+			locked: newGitLabPackageInLockForTest(),
+			pkg:    newGitLabPackageForLockTest(),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.pkg, tt.locked.Package())
+		})
+	}
+}
+
+// newGitLabPackageForLockTest return package which has source, platform, constraint and extras to be recorded in lock file.
+func newGitLabPackageForLockTest() Package {
+	pkg := New(NewRepository("gitlab-org/cli", "glab"), NewRelease("v1.31.0"), NewAsset("https://gitlab.com/gitlab-org/cli/-/releases/v1.31.0/downloads/glab_1.31.0_Linux_arm64.tar.gz"), NewExecBinary("glab"), Checksum{})
+	pkg.Source = SourceGitLab
+	pkg.Platform = NewPlatform("linux", "arm64")
+	pkg.Constraint = NewConstraint("^1.31")
+	pkg.Extras = NewExtras(true, []Extra{NewExtra("*/contrib/glab.sh", ExtraBashCompletion)})
+	return pkg
+}
+
+// newGitLabPackageInLockForTest return package metadata in lock file which records package returned by newGitLabPackageForLockTest.
+func newGitLabPackageInLockForTest() PackageInLock {
+	locked := NewPackageInLock("gitlab-org/cli", "glab", "v1.31.0", "https://gitlab.com/gitlab-org/cli/-/releases/v1.31.0/downloads/glab_1.31.0_Linux_arm64.tar.gz", "glab", "8cd07f3a5ff98f2a78cfc366c13fb123eb8d29c1ca37c79df190425d5b9e424d")
+	locked.Source = SourceGitLab
+	locked.Constraint = NewConstraint("^1.31")
+	locked.OS = "linux"
+	locked.Arch = "arm64"
+	locked.Extras = true
+	locked.DeclaredExtras = []Extra{NewExtra("*/contrib/glab.sh", ExtraBashCompletion)}
+	return locked
+}
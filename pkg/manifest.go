@@ -0,0 +1,57 @@
+package pkg
+
+// Manifest is list of packages to be installed.
+type Manifest struct {
+	Packages []PackageInManifest
+}
+
+// PackageInManifest is package metadata in manifest.
+type PackageInManifest struct {
+	Query      string   `yaml:"query"`
+	OS         string   `yaml:"os"`
+	Arch       string   `yaml:"arch"`
+	ExecBinary FileName `yaml:"binary"`
+	Extras     bool     `yaml:"extras"`
+}
+
+// NewManifest return new manifest instance.
+func NewManifest(pkgs []PackageInManifest) Manifest {
+	return Manifest{
+		Packages: pkgs,
+	}
+}
+
+// NewPackageInManifest return new package metadata instance in manifest.
+func NewPackageInManifest(query string, os string, arch string, execBinary FileName) PackageInManifest {
+	return PackageInManifest{
+		Query:      query,
+		OS:         os,
+		Arch:       arch,
+		ExecBinary: execBinary,
+	}
+}
+
+// ParseQuery parse query string in manifest and return query instance.
+// If executable binary name is specified in manifest, it is set to query.
+func (p PackageInManifest) ParseQuery() (Query, error) {
+	query, err := ParseQuery(p.Query)
+	if err != nil {
+		return Query{}, err
+	}
+	query.ExecBinary = p.ExecBinary
+	query.Extras = p.Extras
+	return query, nil
+}
+
+// Platform return platform to install package.
+// OS and arch which are not specified in manifest are taken from default platform.
+func (p PackageInManifest) Platform(defaultPlatform Platform) Platform {
+	platform := defaultPlatform
+	if p.OS != "" {
+		platform.OS = p.OS
+	}
+	if p.Arch != "" {
+		platform.Arch = p.Arch
+	}
+	return platform
+}
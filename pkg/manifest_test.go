@@ -0,0 +1,74 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageInManifestParseQuery(t *testing.T) {
+	tests := []struct {
+		name  string
+		pkg   PackageInManifest
+		query Query
+	}{
+		{
+			name:  "hashicorp/terraform=v1.5.0",
+			pkg:   NewPackageInManifest("hashicorp/terraform=v1.5.0", "", "", ""),
+			query: NewQuery(NewRepository("hashicorp", "terraform"), "v1.5.0"),
+		},
+		{
+			name: "cli/cli=v2.21.1",
+			pkg:  NewPackageInManifest("cli/cli=v2.21.1", "", "", "gh"),
+			query: Query{
+				Repository: NewRepository("cli", "cli"),
+				Tag:        "v2.21.1",
+				ExecBinary: "gh",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			query, err := tt.pkg.ParseQuery()
+			assert.NoError(err)
+			assert.Equal(tt.query, query)
+		})
+	}
+}
+
+func TestPackageInManifestPlatform(t *testing.T) {
+	tests := []struct {
+		name            string
+		pkg             PackageInManifest
+		defaultPlatform Platform
+		platform        Platform
+	}{
+		{
+			name:            "default",
+			pkg:             NewPackageInManifest("hashicorp/terraform", "", "", ""),
+			defaultPlatform: NewPlatform("linux", "amd64"),
+			platform:        NewPlatform("linux", "amd64"),
+		},
+		{
+			name:            "override arch",
+			pkg:             NewPackageInManifest("hashicorp/terraform", "", "arm64", ""),
+			defaultPlatform: NewPlatform("linux", "amd64"),
+			platform:        NewPlatform("linux", "arm64"),
+		},
+		{
+			name:            "override os and arch",
+			pkg:             NewPackageInManifest("hashicorp/terraform", "darwin", "arm64", ""),
+			defaultPlatform: NewPlatform("linux", "amd64"),
+			platform:        NewPlatform("darwin", "arm64"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.platform, tt.pkg.Platform(tt.defaultPlatform))
+		})
+	}
+}
@@ -2,17 +2,37 @@ package pkg
 
 import (
 	"fmt"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"golang.org/x/mod/semver"
 )
 
 // Package.
+// ExecBinaryGuessed is true if name of executable binary is guessed, e.g. by repository name.
+// In that case, executable binary is detected by contents of asset if it is not found by name.
 type Package struct {
-	Repository Repository
-	Release    Release
-	Asset      Asset
-	ExecBinary ExecBinary
+	Source            Source
+	Repository        Repository
+	Release           Release
+	Asset             Asset
+	ExecBinary        ExecBinary
+	ExecBinaryGuessed bool
+	Checksum          Checksum
+	Platform          Platform
+	Constraint        Constraint
+	Extras            Extras
+}
+
+// Installation is result of installing package.
+// Extras are installed files bundled with executable binary, such as shell completions.
+type Installation struct {
+	Package     Package
+	Path        string
+	SHA256      Digest
+	InstalledAt time.Time
+	Extras      []InstalledExtra
 }
 
 // Repository.
@@ -27,17 +47,32 @@ type Release struct {
 type Asset GitHubAsset
 
 // ExecBinary.
+// Name is file name of installed executable binary.
+// If Pattern is not empty, executable binary is found in archive by it and renamed to Name on install.
 type ExecBinary struct {
-	Name FileName
+	Name    FileName
+	Pattern ExecBinaryPattern
 }
 
 // New package instance.
-func New(repo Repository, release Release, asset Asset, execBinary ExecBinary) Package {
+func New(repo Repository, release Release, asset Asset, execBinary ExecBinary, checksum Checksum) Package {
 	return Package{
 		Repository: repo,
 		Release:    release,
 		Asset:      asset,
 		ExecBinary: execBinary,
+		Checksum:   checksum,
+	}
+}
+
+// NewInstallation return new installation instance.
+// path is file path of installed executable binary and sha256 is its digest.
+func NewInstallation(pkg Package, path string, sha256 Digest, installedAt time.Time) Installation {
+	return Installation{
+		Package:     pkg,
+		Path:        path,
+		SHA256:      sha256,
+		InstalledAt: installedAt,
 	}
 }
 
@@ -70,9 +105,129 @@ func NewExecBinary(name FileName) ExecBinary {
 	}
 }
 
+// NewExecBinaryWithPattern return new executable binary instance which is found in archive by pattern.
+func NewExecBinaryWithPattern(name FileName, pattern ExecBinaryPattern) ExecBinary {
+	return ExecBinary{
+		Name:    name,
+		Pattern: pattern,
+	}
+}
+
+// Match return true if file at path in archive is executable binary.
+func (b ExecBinary) Match(path string) bool {
+	if b.Pattern.IsEmpty() {
+		return filepath.Base(path) == b.Name.String()
+	}
+	return b.Pattern.Match(path)
+}
+
+// String return name of executable binary, or pattern to find it in archive if it has.
+func (b ExecBinary) String() string {
+	if b.Pattern.IsEmpty() {
+		return b.Name.String()
+	}
+	return b.Pattern.String()
+}
+
+// WithExecBinary return copy of package which installs execBinary from same asset.
+func (p Package) WithExecBinary(execBinary ExecBinary) Package {
+	p.ExecBinary = execBinary
+	p.ExecBinaryGuessed = false
+	return p
+}
+
+// RepositoryName return "<owner>/<repo>".
+// If package is downloaded from URL directly, URL is returned instead.
+func (p Package) RepositoryName() string {
+	if p.Source == SourceURL {
+		return p.Asset.DownloadURL.String()
+	}
+	return fmt.Sprintf("%s/%s", p.Repository.Owner, p.Repository.Name)
+}
+
 // StringToPrompt return string to prompt.
 func (p Package) StringToPrompt() string {
-	return fmt.Sprintf("Repo:\t%s/%s\nTag:\t%s\nAsset:\t%s\nBinary:\t%s", p.Repository.Owner, p.Repository.Name, p.Release.Tag, p.Asset.DownloadURL.FileName().String(), p.ExecBinary.Name)
+	if p.Source == SourceURL {
+		return fmt.Sprintf("URL:\t%s\nBinary:\t%s", p.Asset.DownloadURL, p.ExecBinary.Name)
+	}
+	prompt := fmt.Sprintf("Repo:\t%s/%s\nTag:\t%s\nAsset:\t%s\nBinary:\t%s", p.Repository.Owner, p.Repository.Name, p.Release.Tag, p.Asset.DownloadURL.FileName().String(), p.ExecBinary.Name)
+	if !p.Checksum.IsEmpty() {
+		prompt = fmt.Sprintf("%s\nChecksum:\t%s", prompt, p.Checksum.DownloadURL.FileName())
+	}
+	return prompt
+}
+
+// HasExecBinary return true if asset has exec binary.
+func (a Asset) HasExecBinary() bool {
+	filename := a.DownloadURL.FileName()
+	return filename.IsExecBinary() || filename.IsArchived() || filename.IsCompressed()
+}
+
+// Platform return platform guessed by asset file name.
+func (a Asset) Platform() (Platform, error) {
+	filename := a.DownloadURL.FileName()
+	return filename.Platform()
+}
+
+// FindReleaseByConstraint return release which has highest semver satisfying constraint.
+func FindReleaseByConstraint(releases []Release, constraint Constraint) (Release, error) {
+	var found Release
+	var foundVersion string
+	for _, release := range releases {
+		ok, err := constraint.Check(release)
+		if err != nil {
+			return Release{}, err
+		}
+		if !ok {
+			continue
+		}
+		version, err := release.SemVer()
+		if err != nil {
+			continue
+		}
+		if foundVersion == "" || semver.Compare("v"+version, "v"+foundVersion) > 0 {
+			found = release
+			foundVersion = version
+		}
+	}
+	if foundVersion == "" {
+		return Release{}, fmt.Errorf("%w: no release satisfies %s", ErrReleaseNotFound, constraint)
+	}
+	return found, nil
+}
+
+// FindChecksumAsset find checksum file which has digest of target asset.
+// Checksum file only for target asset (e.g. "<asset>.sha256") is preferred to checksum file for multiple assets (e.g. "checksums.txt").
+func FindChecksumAsset(assets []Asset, target FileName) (Asset, error) {
+	candidates := []Asset{}
+	for _, asset := range assets {
+		filename := asset.DownloadURL.FileName()
+		if filename.IsChecksumForSingleFile() && filename.TrimExt() == target {
+			return asset, nil
+		}
+		if filename.IsChecksumForMultiFiles() {
+			candidates = append(candidates, asset)
+		}
+	}
+	if len(candidates) == 0 {
+		return Asset{}, fmt.Errorf("checksum file for %s was not found", target)
+	}
+	return candidates[0], nil
+}
+
+// FilterAssetByPlatform filter assets which has executable binary for specified platform.
+func FilterAssetByPlatform(assets []Asset, platform Platform) []Asset {
+	result := []Asset{}
+	for _, asset := range assets {
+		p, err := asset.Platform()
+		if err != nil {
+			continue
+		}
+		if asset.HasExecBinary() && platform.Equals(p) {
+			result = append(result, asset)
+		}
+	}
+	return result
 }
 
 // SemVer return semver formatted release tag.
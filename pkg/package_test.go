@@ -20,9 +20,21 @@ func TestPackageStringToPrompt(t *testing.T) {
 				NewRelease("0.12.20"),
 				NewAsset("https://releases.hashicorp.com/terraform/0.12.20/terraform_0.12.20_linux_amd64.zip"),
 				NewExecBinary("terraform"),
+				Checksum{},
 			),
 			prompt: "Repo:\thashicorp/terraform\nTag:\t0.12.20\nAsset:\tterraform_0.12.20_linux_amd64.zip\nBinary:\tterraform",
 		},
+		{
+			name: "hashicorp/terraform with checksum",
+			pkg: New(
+				NewRepository("hashicorp", "terraform"),
+				NewRelease("0.12.20"),
+				NewAsset("https://releases.hashicorp.com/terraform/0.12.20/terraform_0.12.20_linux_amd64.zip"),
+				NewExecBinary("terraform"),
+				NewChecksum("https://releases.hashicorp.com/terraform/0.12.20/terraform_0.12.20_SHA256SUMS"),
+			),
+			prompt: "Repo:\thashicorp/terraform\nTag:\t0.12.20\nAsset:\tterraform_0.12.20_linux_amd64.zip\nBinary:\tterraform\nChecksum:\tterraform_0.12.20_SHA256SUMS",
+		},
 	}
 
 	for _, tt := range tests {
@@ -71,3 +83,204 @@ func TestReleaseSemVer(t *testing.T) {
 		})
 	}
 }
+
+func TestAssetHasExecBinary(t *testing.T) {
+	tests := []struct {
+		name          string
+		asset         Asset
+		hasExecBinary bool
+	}{
+		{
+			name:          "https://github.com/cli/cli/releases/download/v2.21.0/gh_2.21.0_linux_amd64.tar.gz",
+			asset:         NewAsset("https://github.com/cli/cli/releases/download/v2.21.0/gh_2.21.0_linux_amd64.tar.gz"),
+			hasExecBinary: true,
+		},
+		{
+			name:          "https://github.com/cli/cli/releases/download/v2.21.0/gh_2.21.0_checksums.txt",
+			asset:         NewAsset("https://github.com/cli/cli/releases/download/v2.21.0/gh_2.21.0_checksums.txt"),
+			hasExecBinary: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.hasExecBinary, tt.asset.HasExecBinary())
+		})
+	}
+}
+
+func TestAssetPlatform(t *testing.T) {
+	tests := []struct {
+		name     string
+		asset    Asset
+		platform Platform
+	}{
+		{
+			name:     "https://github.com/cli/cli/releases/download/v2.21.0/gh_2.21.0_linux_amd64.tar.gz",
+			asset:    NewAsset("https://github.com/cli/cli/releases/download/v2.21.0/gh_2.21.0_linux_amd64.tar.gz"),
+			platform: NewPlatform("linux", "amd64"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			platform, err := tt.asset.Platform()
+			assert.NoError(err)
+			assert.Equal(tt.platform, platform)
+		})
+	}
+}
+
+func TestFilterAssetByPlatform(t *testing.T) {
+	tests := []struct {
+		name     string
+		assets   []Asset
+		platform Platform
+		filtered []Asset
+	}{
+		{
+			name: "cli/cli",
+			assets: []Asset{
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_checksums.txt"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_386.deb"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_386.rpm"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_386.tar.gz"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.deb"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.rpm"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.tar.gz"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_arm64.deb"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_arm64.rpm"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_arm64.tar.gz"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_armv6.deb"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_armv6.rpm"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_armv6.tar.gz"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_macOS_amd64.tar.gz"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_386.zip"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_amd64.msi"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_amd64.zip"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_windows_arm64.zip"),
+			},
+			platform: NewPlatform("linux", "amd64"),
+			filtered: []Asset{
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.tar.gz"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			filtered := FilterAssetByPlatform(tt.assets, tt.platform)
+			assert.Equal(tt.filtered, filtered)
+		})
+	}
+}
+
+func TestFindChecksumAsset(t *testing.T) {
+	tests := []struct {
+		name     string
+		assets   []Asset
+		target   FileName
+		checksum Asset
+		err      error
+	}{
+		{
+			name: "checksums.txt",
+			assets: []Asset{
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_checksums.txt"),
+				NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_linux_amd64.tar.gz"),
+			},
+			target:   "gh_2.21.1_linux_amd64.tar.gz",
+			checksum: NewAsset("https://github.com/cli/cli/releases/download/v2.21.1/gh_2.21.1_checksums.txt"),
+		},
+		{
+			name: "<asset>.sha256",
+			assets: []Asset{
+				NewAsset("https://github.com/owner/repo/releases/download/v1.0.0/SHA256SUMS"),
+				NewAsset("https://github.com/owner/repo/releases/download/v1.0.0/SHA256SUMS.sig"),
+				NewAsset("https://github.com/owner/repo/releases/download/v1.0.0/repo_linux_arm64.tar.gz"),
+				NewAsset("https://github.com/owner/repo/releases/download/v1.0.0/repo_linux_arm64.tar.gz.sha256"),
+				NewAsset("https://github.com/owner/repo/releases/download/v1.0.0/repo_linux_amd64.tar.gz"),
+				NewAsset("https://github.com/owner/repo/releases/download/v1.0.0/repo_linux_amd64.tar.gz.sha256"),
+			},
+			target:   "repo_linux_amd64.tar.gz",
+			checksum: NewAsset("https://github.com/owner/repo/releases/download/v1.0.0/repo_linux_amd64.tar.gz.sha256"),
+		},
+		{
+			name: "not found",
+			assets: []Asset{
+				NewAsset("https://github.com/owner/repo/releases/download/v1.0.0/repo_linux_arm64.tar.gz.sha256"),
+				NewAsset("https://github.com/owner/repo/releases/download/v1.0.0/repo_linux_amd64.tar.gz"),
+			},
+			target: "repo_linux_amd64.tar.gz",
+			err:    fmt.Errorf("checksum file for repo_linux_amd64.tar.gz was not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			checksum, err := FindChecksumAsset(tt.assets, tt.target)
+			if tt.err == nil {
+				assert.NoError(err)
+				assert.Equal(tt.checksum, checksum)
+			} else {
+				assert.EqualError(err, tt.err.Error())
+			}
+		})
+	}
+}
+
+func TestFindReleaseByConstraint(t *testing.T) {
+	releases := []Release{
+		NewRelease("v1.3.0"),
+		NewRelease("v1.4.0"),
+		NewRelease("v1.4.2"),
+		NewRelease("v1.5.0-rc.1"),
+		NewRelease("v2.0.0"),
+		NewRelease("nightly"),
+	}
+
+	tests := []struct {
+		name       string
+		constraint Constraint
+		release    Release
+		err        error
+	}{
+		{
+			name:       "^1.4",
+			constraint: "^1.4",
+			release:    NewRelease("v1.4.2"),
+		},
+		{
+			name:       "~1.3",
+			constraint: "~1.3",
+			release:    NewRelease("v1.3.0"),
+		},
+		{
+			name:       ">=1.0",
+			constraint: ">=1.0",
+			release:    NewRelease("v2.0.0"),
+		},
+		{
+			name:       "^3",
+			constraint: "^3",
+			err:        fmt.Errorf("%w: no release satisfies ^3", ErrReleaseNotFound),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			release, err := FindReleaseByConstraint(releases, tt.constraint)
+			if tt.err == nil {
+				assert.NoError(err)
+				assert.Equal(tt.release, release)
+			} else {
+				assert.EqualError(err, tt.err.Error())
+			}
+		})
+	}
+}
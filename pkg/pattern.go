@@ -0,0 +1,97 @@
+package pkg
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// ExecBinaryPattern is pattern to find executable binary in archive by its path.
+// Glob is pattern of path.Match and Regexp is regular expression. Either of them should be set.
+// Each of them is matched against whole path in archive and its base name.
+type ExecBinaryPattern struct {
+	Glob   string `yaml:"glob,omitempty"`
+	Regexp string `yaml:"regexp,omitempty"`
+}
+
+// compiledRegexps caches compiled regular expressions of executable binary patterns,
+// so that each of them is compiled once rather than per file in archive.
+var compiledRegexps sync.Map
+
+// NewExecBinaryPattern return new executable binary pattern instance.
+func NewExecBinaryPattern(glob string, regexp string) ExecBinaryPattern {
+	return ExecBinaryPattern{
+		Glob:   glob,
+		Regexp: regexp,
+	}
+}
+
+// RenderExecBinaryPattern render glob and regexp templates with release and platform and return pattern.
+// Values embedded into regexp are escaped.
+func RenderExecBinaryPattern(glob string, re string, release Release, platform Platform) (ExecBinaryPattern, error) {
+	param := newTemplateParam(release, platform)
+	renderedGlob, err := renderTemplate(glob, param)
+	if err != nil {
+		return ExecBinaryPattern{}, err
+	}
+	renderedRegexp, err := renderTemplate(re, param.quoted(regexp.QuoteMeta))
+	if err != nil {
+		return ExecBinaryPattern{}, err
+	}
+	pattern := NewExecBinaryPattern(renderedGlob, renderedRegexp)
+	if _, err := pattern.compiledRegexp(); err != nil {
+		return ExecBinaryPattern{}, err
+	}
+	if _, err := path.Match(renderedGlob, ""); err != nil {
+		return ExecBinaryPattern{}, fmt.Errorf("%s: %w", renderedGlob, err)
+	}
+	return pattern, nil
+}
+
+// IsEmpty return true if pattern is not set.
+func (p ExecBinaryPattern) IsEmpty() bool {
+	return p.Glob == "" && p.Regexp == ""
+}
+
+// Match return true if file at path in archive matches pattern.
+func (p ExecBinaryPattern) Match(name string) bool {
+	name = strings.TrimPrefix(path.Clean(strings.ReplaceAll(name, "\\", "/")), "./")
+	candidates := []string{name, path.Base(name)}
+	for _, candidate := range candidates {
+		if p.Glob != "" {
+			if matched, err := path.Match(p.Glob, candidate); err == nil && matched {
+				return true
+			}
+		}
+		if p.Regexp != "" {
+			if re, err := p.compiledRegexp(); err == nil && re.MatchString(candidate) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// String return pattern as string.
+func (p ExecBinaryPattern) String() string {
+	if p.Glob != "" {
+		return p.Glob
+	}
+	return p.Regexp
+}
+
+// compiledRegexp return compiled regular expression of pattern.
+// It is compiled when it is used first time and cached.
+func (p ExecBinaryPattern) compiledRegexp() (*regexp.Regexp, error) {
+	if re, ok := compiledRegexps.Load(p.Regexp); ok {
+		return re.(*regexp.Regexp), nil
+	}
+	re, err := regexp.Compile(p.Regexp)
+	if err != nil {
+		return nil, err
+	}
+	compiledRegexps.Store(p.Regexp, re)
+	return re, nil
+}
@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecBinaryMatch(t *testing.T) {
+	tests := []struct {
+		name       string
+		execBinary ExecBinary
+		path       string
+		matched    bool
+	}{
+		{
+			name:       "name",
+			execBinary: NewExecBinary("tool"),
+			path:       "tool_v1.2.3_linux_amd64/tool",
+			matched:    true,
+		},
+		{
+			name:       "name does not match versioned file",
+			execBinary: NewExecBinary("tool"),
+			path:       "tool_v1.2.3_linux_amd64",
+			matched:    false,
+		},
+		{
+			name:       "glob matches base name",
+			execBinary: NewExecBinaryWithPattern("tool", NewExecBinaryPattern("tool_v*_linux_amd64", "")),
+			path:       "dist/tool_v1.2.3_linux_amd64",
+			matched:    true,
+		},
+		{
+			name:       "glob matches whole path",
+			execBinary: NewExecBinaryWithPattern("tool", NewExecBinaryPattern("*/bin/tool-*", "")),
+			path:       "./tool_v1.2.3/bin/tool-linux",
+			matched:    true,
+		},
+		{
+			name:       "glob does not match",
+			execBinary: NewExecBinaryWithPattern("tool", NewExecBinaryPattern("tool_v*_linux_amd64", "")),
+			path:       "tool_v1.2.3_darwin_arm64",
+			matched:    false,
+		},
+		{
+			name:       "regexp",
+			execBinary: NewExecBinaryWithPattern("tool", NewExecBinaryPattern("", `^tool_v\d+\.\d+\.\d+_linux_amd64$`)),
+			path:       "tool/tool_v1.2.3_linux_amd64",
+			matched:    true,
+		},
+		{
+			name:       "regexp does not match",
+			execBinary: NewExecBinaryWithPattern("tool", NewExecBinaryPattern("", `^tool_v\d+\.\d+\.\d+_linux_amd64$`)),
+			path:       "tool/tool_v1.2.3_linux_amd64.sha256",
+			matched:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.matched, tt.execBinary.Match(tt.path))
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package pkg
+
+import (
+	"io"
+
+	"github.com/cheggaaa/pb/v3"
+)
+
+// ProgressBarPool draws progress bars of concurrent downloads together.
+type ProgressBarPool struct {
+	pool *pb.Pool
+	bars []*pb.ProgressBar
+}
+
+// progressBarInPool is progress bar in pool.
+// It is passed as progress bar writer to Install so that download progress is drawn by pool.
+type progressBarInPool struct {
+	bar *pb.ProgressBar
+}
+
+// NewProgressBarPool return new progress bar pool which has progress bar for each of names, and start drawing them to w.
+// This return error if w can't draw multiple progress bars, e.g. it is not terminal.
+func NewProgressBarPool(w io.Writer, names []string) (*ProgressBarPool, error) {
+	bars := []*pb.ProgressBar{}
+	for _, name := range names {
+		bars = append(bars, pb.Full.New(0).Set("prefix", name+" "))
+	}
+	pool := pb.NewPool(bars...)
+	pool.Output = w
+	if err := pool.Start(); err != nil {
+		return nil, err
+	}
+	return &ProgressBarPool{
+		pool: pool,
+		bars: bars,
+	}, nil
+}
+
+// ProgressBar return i-th progress bar in pool as progress bar writer.
+func (p *ProgressBarPool) ProgressBar(i int) io.Writer {
+	return progressBarInPool{bar: p.bars[i]}
+}
+
+// Stop finish all progress bars and stop drawing them.
+func (p *ProgressBarPool) Stop() error {
+	for _, bar := range p.bars {
+		bar.Finish()
+	}
+	return p.pool.Stop()
+}
+
+// Write discard b because progress bar in pool is drawn by pool.
+func (p progressBarInPool) Write(b []byte) (int, error) {
+	return len(b), nil
+}
+
+// startProgressBar start progress bar which is drawn to w and return it with function to finish it.
+// If w is progress bar in pool, it is returned instead of new one. It is finished by owner of pool by Stop, not by returned function,
+// because same progress bar in pool may be used by multiple downloads.
+func startProgressBar(w io.Writer) (*pb.ProgressBar, func()) {
+	if p, ok := w.(progressBarInPool); ok {
+		return p.bar, func() {}
+	}
+	bar := pb.Full.New(0).SetWriter(w).Start()
+	return bar, func() { bar.Finish() }
+}
@@ -1,16 +1,23 @@
 package pkg
 
 import (
-	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
 	"net/http"
+	"net/url"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
-	"github.com/cheggaaa/pb/v3"
 	"github.com/google/go-github/v48/github"
 	"golang.org/x/oauth2"
 	"gopkg.in/yaml.v3"
@@ -21,22 +28,82 @@ import (
 //go:embed index.yaml
 var BuiltInIndex []byte
 
+// defaultGitHubAPIURL is API endpoint of github.com.
+const defaultGitHubAPIURL = "https://api.github.com/"
+
 // InfrastructureRepository for package domain.
 type InfrastructureRepository struct {
-	github *github.Client
+	github      *github.Client
+	token       string
+	githubHost  string
+	gitlabURL   string
+	gitlabToken string
+	giteaURL    string
+	giteaToken  string
+	indexPaths  []string
+	offline     bool
+	downloading sync.Map
+	client      *http.Client
+}
+
+// InfrastructureOptions is options of infrastructure repository.
+// If GitHubURL is not empty and is not public GitHub API, GitHub Enterprise Server client is used.
+// If GitLabURL is empty, gitlab.com is used. If GiteaURL is empty, codeberg.org is used.
+// IndexPaths are paths of user-supplied index files which take precedence over built-in index.
+// If Offline is true, repositories, releases, assets and remote indices are read only from local cache.
+type InfrastructureOptions struct {
+	Token       string
+	GitHubURL   string
+	GitLabURL   string
+	GitLabToken string
+	GiteaURL    string
+	GiteaToken  string
+	IndexPaths  []string
+	Offline     bool
 }
 
 // NewInfrastructureRepository return new infrastructure repository instance.
-func NewInfrastructureRepository(ctx context.Context, token string) *InfrastructureRepository {
+func NewInfrastructureRepository(ctx context.Context, opts InfrastructureOptions) (*InfrastructureRepository, error) {
 	var httpClient *http.Client
-	if token != "" {
-		tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
+	if opts.Token != "" {
+		tokenSource := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: opts.Token})
 		httpClient = oauth2.NewClient(ctx, tokenSource)
 	}
 	githubClient := github.NewClient(httpClient)
-	return &InfrastructureRepository{
-		github: githubClient,
+	githubHost := ""
+	if opts.GitHubURL != "" && !isPublicGitHubAPI(opts.GitHubURL) {
+		var err error
+		githubClient, err = github.NewEnterpriseClient(opts.GitHubURL, opts.GitHubURL, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		githubHost = githubClient.BaseURL.Host
+	}
+	gitlabURL := opts.GitLabURL
+	if gitlabURL == "" {
+		gitlabURL = defaultGitLabURL
+	}
+	giteaURL := opts.GiteaURL
+	if giteaURL == "" {
+		giteaURL = defaultCodebergURL
 	}
+	return &InfrastructureRepository{
+		github:      githubClient,
+		token:       opts.Token,
+		githubHost:  githubHost,
+		gitlabURL:   gitlabURL,
+		gitlabToken: opts.GitLabToken,
+		giteaURL:    giteaURL,
+		giteaToken:  opts.GiteaToken,
+		indexPaths:  opts.IndexPaths,
+		offline:     opts.Offline,
+		client:      newDownloadClient(),
+	}, nil
+}
+
+// isPublicGitHubAPI return true if url is API endpoint of github.com.
+func isPublicGitHubAPI(url string) bool {
+	return strings.TrimSuffix(url, "/") == strings.TrimSuffix(defaultGitHubAPIURL, "/")
 }
 
 // SearchGitHubRepository search GitHub repository.
@@ -47,7 +114,7 @@ func (r *InfrastructureRepository) SearchGitHubRepository(ctx context.Context, q
 	}
 	repos := result.Repositories
 	if len(repos) == 0 {
-		return GitHubRepository{}, fmt.Errorf("no repository was found")
+		return GitHubRepository{}, fmt.Errorf("%w by query %s", ErrRepositoryNotFound, query)
 	}
 	repo := repos[0]
 	return NewGitHubRepository(repo.GetOwner().GetLogin(), repo.GetName()), nil
@@ -55,7 +122,10 @@ func (r *InfrastructureRepository) SearchGitHubRepository(ctx context.Context, q
 
 // FindGitHubRepository find GitHub repository.
 func (r *InfrastructureRepository) FindGitHubRepository(ctx context.Context, owner string, name string) (GitHubRepository, error) {
-	repo, _, err := r.github.Repositories.Get(ctx, owner, name)
+	repo, resp, err := r.github.Repositories.Get(ctx, owner, name)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return GitHubRepository{}, fmt.Errorf("%w: %s/%s", ErrRepositoryNotFound, owner, name)
+	}
 	if err != nil {
 		return GitHubRepository{}, err
 	}
@@ -64,16 +134,44 @@ func (r *InfrastructureRepository) FindGitHubRepository(ctx context.Context, own
 
 // LatestGitHubRelease return latest GitHub release.
 func (r *InfrastructureRepository) LatestGitHubRelease(ctx context.Context, repo GitHubRepository) (GitHubRelease, error) {
-	release, _, err := r.github.Repositories.GetLatestRelease(ctx, repo.Owner, repo.Name)
+	release, resp, err := r.github.Repositories.GetLatestRelease(ctx, repo.Owner, repo.Name)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return GitHubRelease{}, fmt.Errorf("%w in %s/%s", ErrReleaseNotFound, repo.Owner, repo.Name)
+	}
 	if err != nil {
 		return GitHubRelease{}, err
 	}
 	return NewGitHubRelease(release.GetID(), release.GetTagName()), nil
 }
 
+// ListGitHubReleases list published releases in GitHub repository.
+func (r *InfrastructureRepository) ListGitHubReleases(ctx context.Context, repo GitHubRepository) ([]GitHubRelease, error) {
+	result := []GitHubRelease{}
+	for page := 1; page != 0; {
+		releases, resp, err := r.github.Repositories.ListReleases(ctx, repo.Owner, repo.Name, &github.ListOptions{
+			Page:    page,
+			PerPage: 100,
+		})
+		if err != nil {
+			return result, err
+		}
+		for _, release := range releases {
+			if release.GetDraft() {
+				continue
+			}
+			result = append(result, NewGitHubRelease(release.GetID(), release.GetTagName()))
+		}
+		page = resp.NextPage
+	}
+	return result, nil
+}
+
 // FindGitHubReleaseByTag return GitHub release by tag.
 func (r *InfrastructureRepository) FindGitHubReleaseByTag(ctx context.Context, repo GitHubRepository, tag string) (GitHubRelease, error) {
-	release, _, err := r.github.Repositories.GetReleaseByTag(ctx, repo.Owner, repo.Name, tag)
+	release, resp, err := r.github.Repositories.GetReleaseByTag(ctx, repo.Owner, repo.Name, tag)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return GitHubRelease{}, fmt.Errorf("%w: %s in %s/%s", ErrReleaseNotFound, tag, repo.Owner, repo.Name)
+	}
 	if err != nil {
 		return GitHubRelease{}, err
 	}
@@ -102,37 +200,820 @@ func (r *InfrastructureRepository) ListGitHubAssets(ctx context.Context, repo Gi
 
 // LoadBuiltInIndex load and return built-in index.
 func (r *InfrastructureRepository) LoadBuiltInIndex() (Index, error) {
+	return parseIndex(BuiltInIndex)
+}
+
+// LoadIndexFile load and return index from file.
+func (r *InfrastructureRepository) LoadIndexFile(path string) (Index, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Index{}, err
+	}
+	index, err := parseIndex(b)
+	if err != nil {
+		return Index{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return index, nil
+}
+
+// LoadIndex load built-in index and user-supplied index files, and return index merged them.
+// Index files are merged in following order, so latter take precedence over former.
+//
+//  1. Built-in index
+//  2. "$XDG_CONFIG_HOME/go-get-release/index.d/*.yaml"
+//  3. Comma separated paths in "$GO_GET_RELEASE_INDEX"
+//  4. Paths passed to NewInfrastructureRepository
+//
+// Path may be HTTP(S) URL. In this case, index is fetched from it.
+func (r *InfrastructureRepository) LoadIndex() (Index, error) {
+	index, err := r.LoadBuiltInIndex()
+	if err != nil {
+		return Index{}, err
+	}
+	paths, err := userIndexPaths()
+	if err != nil {
+		return Index{}, err
+	}
+	paths = append(paths, r.indexPaths...)
+	for _, path := range paths {
+		var userIndex Index
+		if url := NewURL(path); url.IsHTTP() {
+			userIndex, err = r.LoadRemoteIndex(url)
+		} else {
+			userIndex, err = r.LoadIndexFile(path)
+		}
+		if err != nil {
+			return Index{}, err
+		}
+		index = index.Merge(userIndex)
+	}
+	return index, nil
+}
+
+// remoteIndexTimeout is timeout to fetch remote index. Cached index is used if it is exceeded.
+var remoteIndexTimeout = 10 * time.Second
+
+// remoteIndexCache is remote index cached locally with its metadata.
+// They are kept in one file not to be out of sync with each other.
+type remoteIndexCache struct {
+	ETag         string `json:"etag"`
+	LastModified string `json:"lastModified"`
+	Body         string `json:"body"`
+}
+
+// LoadRemoteIndex load and return index from HTTP(S) URL.
+// Fetched index is cached locally and revalidated by ETag or Last-Modified header next time.
+// If index can't be fetched in remoteIndexTimeout or fetched one is not valid index, e.g. login page of captive portal, cached one is returned.
+// In offline mode, cached index is returned without fetching.
+func (r *InfrastructureRepository) LoadRemoteIndex(url URL) (Index, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return Index{}, err
+	}
+	path := filepath.Join(dir, "index", cacheKey(url.String())+".json")
+
+	cached := remoteIndexCache{}
+	b, cacheErr := os.ReadFile(path)
+	if cacheErr == nil {
+		cacheErr = json.Unmarshal(b, &cached)
+	}
+	useCache := func(err error) (Index, error) {
+		if cacheErr != nil {
+			return Index{}, err
+		}
+		return parseIndex([]byte(cached.Body))
+	}
+	if r.offline {
+		return useCache(fmt.Errorf("%w: index %s", ErrNotCached, url))
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url.String(), nil)
+	if err != nil {
+		return Index{}, err
+	}
+	if cacheErr == nil {
+		if cached.ETag != "" {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+		if cached.LastModified != "" {
+			req.Header.Set("If-Modified-Since", cached.LastModified)
+		}
+	}
+	client := &http.Client{Timeout: remoteIndexTimeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return useCache(err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotModified:
+		return useCache(fmt.Errorf("%s: cached index was not found", url))
+	case resp.StatusCode != http.StatusOK:
+		return useCache(fmt.Errorf("%s: unexpected status %s", url, resp.Status))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return useCache(err)
+	}
+	index, err := parseIndex(body)
+	if err != nil {
+		return useCache(fmt.Errorf("%s: %w", url, err))
+	}
+
+	b, err = json.Marshal(remoteIndexCache{
+		ETag:         resp.Header.Get("ETag"),
+		LastModified: resp.Header.Get("Last-Modified"),
+		Body:         string(body),
+	})
+	if err != nil {
+		return Index{}, err
+	}
+	if err := r.writeFile(path, b, 0644); err != nil {
+		return Index{}, err
+	}
+	return index, nil
+}
+
+// parseIndex parse YAML formatted index.
+func parseIndex(b []byte) (Index, error) {
 	repos := []RepositoryInIndex{}
-	err := yaml.Unmarshal(BuiltInIndex, &repos)
+	err := yaml.Unmarshal(b, &repos)
 	if err != nil {
 		return Index{}, err
 	}
 	return NewIndex(repos), nil
 }
 
-// Download file.
+// userIndexPaths return paths of index files in config directory and "$GO_GET_RELEASE_INDEX".
+func userIndexPaths() ([]string, error) {
+	dir, err := configDir()
+	if err != nil {
+		return nil, err
+	}
+	paths, err := filepath.Glob(filepath.Join(dir, "index.d", "*.yaml"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	for _, path := range strings.Split(os.Getenv("GO_GET_RELEASE_INDEX"), ",") {
+		if path = strings.TrimSpace(path); path != "" {
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// LoadManifest load and return manifest from file.
+func (r *InfrastructureRepository) LoadManifest(path string) (Manifest, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Manifest{}, err
+	}
+	pkgs := []PackageInManifest{}
+	err = yaml.Unmarshal(b, &pkgs)
+	if err != nil {
+		return Manifest{}, err
+	}
+	return NewManifest(pkgs), nil
+}
+
+// LoadLock load and return lock file.
+func (r *InfrastructureRepository) LoadLock(path string) (Lock, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return Lock{}, err
+	}
+	pkgs := []PackageInLock{}
+	err = yaml.Unmarshal(b, &pkgs)
+	if err != nil {
+		return Lock{}, err
+	}
+	return NewLock(pkgs), nil
+}
+
+// WriteLock write lock file.
+// Lock file is written atomically not to be broken when writing it is interrupted.
+func (r *InfrastructureRepository) WriteLock(path string, lock Lock) error {
+	b, err := yaml.Marshal(lock.Packages)
+	if err != nil {
+		return err
+	}
+	return r.writeFile(path, b, 0644)
+}
+
+// LoadInventory load and return inventory of installed packages.
+// If inventory file doesn't exist, empty inventory is returned.
+func (r *InfrastructureRepository) LoadInventory() (Inventory, error) {
+	path, err := inventoryPath()
+	if err != nil {
+		return Inventory{}, err
+	}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewInventory(map[string]PackageInInventory{}), nil
+	}
+	if err != nil {
+		return Inventory{}, err
+	}
+	pkgs := map[string]PackageInInventory{}
+	err = json.Unmarshal(b, &pkgs)
+	if err != nil {
+		return Inventory{}, err
+	}
+	return NewInventory(pkgs), nil
+}
+
+// WriteInventory write inventory of installed packages.
+func (r *InfrastructureRepository) WriteInventory(inventory Inventory) error {
+	path, err := inventoryPath()
+	if err != nil {
+		return err
+	}
+	b, err := json.MarshalIndent(inventory.Packages, "", "  ")
+	if err != nil {
+		return err
+	}
+	return r.writeFile(path, b, 0644)
+}
+
+// inventoryPath return path of inventory file.
+func inventoryPath() (string, error) {
+	dir, err := dataDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "installed.json"), nil
+}
+
+// Download file and return it.
+// File downloaded by this, e.g. checksum file, may be changed on server without changing its URL,
+// so it is always downloaded again and cached one is used only in offline mode.
+// If file is GitHub release asset which can't be downloaded directly, e.g. asset in private repository,
+// it is downloaded through GitHub releases asset API with token.
 func (r *InfrastructureRepository) Download(url URL, progressBar io.Writer) (File, error) {
-	resp, err := http.Get(url.String())
+	file, err := r.downloadToCache(url, "", r.offline, progressBar)
 	if err != nil {
 		return File{}, err
 	}
-	defer resp.Body.Close()
+	defer file.Close()
+	body, err := io.ReadAll(file)
+	if err != nil {
+		return File{}, err
+	}
+	return NewFile(url.FileName(), body), nil
+}
+
+// DownloadToCache download file to local download cache without buffering it in memory and return opened cached file.
+// If expected is not empty, it is digest of file found in checksum file and file is cached separately per it,
+// so file which was changed on server is never confused with one cached before.
+// If file is already cached and its digest is same as recorded one, it is returned without downloading.
+// In offline mode, file is never downloaded and this return ErrNotCached if it is not cached.
+// Concurrent downloads of same URL are serialized, so file is downloaded only once.
+// Caller must close returned file.
+func (r *InfrastructureRepository) DownloadToCache(url URL, expected Digest, progressBar io.Writer) (*os.File, error) {
+	return r.downloadToCache(url, expected, true, progressBar)
+}
+
+// downloadToCache download file to local download cache and return opened cached file.
+// If useCache is false, file is downloaded even if it is already cached.
+// Cached file without expected digest is revalidated by its ETag or Last-Modified unless offline,
+// because file at same URL may be changed, e.g. "latest" URL.
+// If cache directory is not writable, file is downloaded to temporary file instead.
+func (r *InfrastructureRepository) downloadToCache(url URL, expected Digest, useCache bool, progressBar io.Writer) (*os.File, error) {
+	mu, _ := r.downloading.LoadOrStore(url, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+	defer mu.(*sync.Mutex).Unlock()
+
+	bodyPath, metaPath, err := assetCachePaths(url, expected)
+	if err != nil {
+		return nil, err
+	}
+	if useCache {
+		if file, cached, err := r.openCachedAsset(url, expected); err == nil {
+			if expected != "" || r.offline || r.isNotModified(url, cached.Validator) {
+				return file, nil
+			}
+			file.Close()
+		}
+	}
+	if r.offline {
+		return nil, fmt.Errorf("%w: %s", ErrNotCached, url)
+	}
+
+	partialPath, err := partialFilePath(url)
+	if err != nil {
+		return nil, err
+	}
+	if err := createPartialFile(partialPath); err != nil {
+		return r.downloadToTempFile(url, progressBar)
+	}
+	if err := r.downloadToPartialFile(url, partialPath, progressBar); err != nil {
+		return nil, err
+	}
+	file, err := os.Open(partialPath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	sha256, err := SHA256Reader(file)
+	if err != nil {
+		return nil, err
+	}
+	validator, err := os.ReadFile(validatorPath(partialPath))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	now := time.Now()
+	asset := NewAssetInCache(url, sha256, info.Size(), bodyPath, now, now)
+	asset.ExpectedDigest = expected
+	asset.Validator = string(validator)
+	if err := os.MkdirAll(filepath.Dir(bodyPath), 0755); err != nil {
+		return nil, err
+	}
+	if err := os.Rename(partialPath, bodyPath); err != nil {
+		return nil, err
+	}
+	if err := writeValidator(partialPath, ""); err != nil {
+		return nil, err
+	}
+	if err := writeAssetInCache(metaPath, asset); err != nil {
+		return nil, err
+	}
+	return os.Open(bodyPath)
+}
+
+// downloadToTempFile download file to temporary file outside of cache directory and return it opened.
+// This is used when cache directory is not writable. Temporary file is removed right after it is opened,
+// so it is released when returned file is closed. On platforms which can't remove opened file, it is left in temporary directory.
+func (r *InfrastructureRepository) downloadToTempFile(url URL, progressBar io.Writer) (*os.File, error) {
+	dir, err := os.MkdirTemp("", "go-get-release-*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, url.FileName().String()+".part")
+	if err := r.downloadToPartialFile(url, path, progressBar); err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
 
-	bar := pb.Full.Start64(resp.ContentLength).SetWriter(progressBar)
-	src := bar.NewProxyReader(resp.Body)
+// openCachedAsset open cached file downloaded from url and return it with its metadata.
+// If cached file is corrupted, this return error.
+// Last used time of cached file is updated only if cache is writable.
+func (r *InfrastructureRepository) openCachedAsset(url URL, expected Digest) (*os.File, AssetInCache, error) {
+	bodyPath, metaPath, err := assetCachePaths(url, expected)
+	if err != nil {
+		return nil, AssetInCache{}, err
+	}
+	asset, err := readAssetInCache(metaPath)
+	if err != nil {
+		return nil, AssetInCache{}, err
+	}
+	file, err := os.Open(bodyPath)
+	if err != nil {
+		return nil, AssetInCache{}, err
+	}
+	if err := VerifyReader(file, url.FileName(), asset.SHA256); err != nil {
+		file.Close()
+		return nil, AssetInCache{}, err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		file.Close()
+		return nil, AssetInCache{}, err
+	}
+	asset.LastUsedAt = time.Now()
+	_ = writeAssetInCache(metaPath, asset)
+	return file, asset, nil
+}
 
-	dst := new(bytes.Buffer)
+// isNotModified return true if file at url is not modified since it had validator, i.e. ETag or Last-Modified.
+// This is checked by conditional GET request. If validator is empty or request fails, file is regarded as modified.
+func (r *InfrastructureRepository) isNotModified(url URL, validator string) bool {
+	if validator == "" {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), downloadTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url.String(), nil)
+	if err != nil {
+		return false
+	}
+	r.authorize(req)
+	if strings.HasPrefix(validator, `"`) {
+		req.Header.Set("If-None-Match", validator)
+	} else {
+		req.Header.Set("If-Modified-Since", validator)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode == http.StatusNotModified
+}
+
+// partialFilePath return path of partial file in cache directory which file downloaded from url is written to.
+func partialFilePath(url URL) (string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "downloads", cacheKey(url.String())+"-"+url.FileName().String()+".part"), nil
+}
+
+// createPartialFile create partial file at path unless it exists. This return error if cache directory is not writable.
+func createPartialFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	return file.Close()
+}
+
+// downloadToPartialFile download file to partial file at path.
+// If download fails on the way, partial file is left and next download resumes it with Range request.
+// ETag or Last-Modified of file is stored next to partial file and sent by If-Range header,
+// so download is restarted from the beginning if file was changed. Partial file without them is never resumed.
+// Request is retried with exponential backoff on transient errors.
+func (r *InfrastructureRepository) downloadToPartialFile(url URL, path string, progressBar io.Writer) error {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	bar, finish := startProgressBar(progressBar)
+	defer finish()
+
+	return retry(func() error {
+		offset, err := file.Seek(0, io.SeekEnd)
+		if err != nil {
+			return err
+		}
+		validator, err := os.ReadFile(validatorPath(path))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		if len(validator) == 0 {
+			offset = 0
+		}
+		remote, err := r.open(context.Background(), url, offset, string(validator))
+		if err != nil {
+			return err
+		}
+		defer remote.body.Close()
+		if !remote.resumed {
+			if err := file.Truncate(0); err != nil {
+				return err
+			}
+			if offset, err = file.Seek(0, io.SeekStart); err != nil {
+				return err
+			}
+			if err := writeValidator(path, remote.validator); err != nil {
+				return err
+			}
+		}
+		// Total is unknown if server doesn't send Content-Length.
+		total := int64(0)
+		if remote.size >= 0 {
+			total = offset + remote.size
+		}
+		bar.SetTotal(total).SetCurrent(offset)
+		_, err = io.Copy(file, bar.NewProxyReader(remote.body))
+		return err
+	})
+}
+
+// validatorPath return path of file which stores ETag or Last-Modified of partial file at partialPath.
+func validatorPath(partialPath string) string {
+	return strings.TrimSuffix(partialPath, ".part") + ".validator"
+}
+
+// writeValidator store ETag or Last-Modified of partial file at partialPath.
+// If validator is empty, stored one is removed so that partial file is not resumed.
+func writeValidator(partialPath string, validator string) error {
+	if validator == "" {
+		if err := os.Remove(validatorPath(partialPath)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+		return nil
+	}
+	return os.WriteFile(validatorPath(partialPath), []byte(validator), 0644)
+}
+
+// ListCachedAssets return assets in local download cache sorted by URL.
+// Assets whose metadata is corrupted are skipped and reported by returned error with other assets.
+func (r *InfrastructureRepository) ListCachedAssets() ([]AssetInCache, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return nil, err
+	}
+	metaPaths, err := filepath.Glob(filepath.Join(dir, "assets", "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	assets := []AssetInCache{}
+	errs := []error{}
+	for _, metaPath := range metaPaths {
+		asset, err := readAssetInCache(metaPath)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("corrupted cache: %w", err))
+			continue
+		}
+		assets = append(assets, asset)
+	}
+	sort.Slice(assets, func(i, j int) bool { return assets[i].URL < assets[j].URL })
+	return assets, errors.Join(errs...)
+}
+
+// RemoveCachedAsset remove asset and its metadata from local download cache.
+func (r *InfrastructureRepository) RemoveCachedAsset(asset AssetInCache) error {
+	return r.EvictCachedAsset(asset.URL, asset.ExpectedDigest)
+}
+
+// EvictCachedAsset remove asset downloaded from url with expected digest and its metadata from local download cache.
+// It is not error if asset is not cached.
+func (r *InfrastructureRepository) EvictCachedAsset(url URL, expected Digest) error {
+	bodyPath, metaPath, err := assetCachePaths(url, expected)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(bodyPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	if err := os.Remove(metaPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// CleanCache remove all files in cache directory, including cached assets, partial files and remote indices.
+func (r *InfrastructureRepository) CleanCache() error {
+	dir, err := cacheDir()
+	if err != nil {
+		return err
+	}
+	return os.RemoveAll(dir)
+}
 
-	_, err = io.Copy(dst, src)
+// assetCachePaths return paths of cached asset downloaded from url with expected digest and its metadata.
+func assetCachePaths(url URL, expected Digest) (string, string, error) {
+	dir, err := cacheDir()
+	if err != nil {
+		return "", "", err
+	}
+	key := url.String()
+	if expected != "" {
+		key = key + " " + expected.String()
+	}
+	key = cacheKey(key)
+	bodyPath := filepath.Join(dir, "assets", key+"-"+url.FileName().String())
+	metaPath := filepath.Join(dir, "assets", key+".json")
+	return bodyPath, metaPath, nil
+}
+
+// readAssetInCache read metadata of cached asset.
+func readAssetInCache(metaPath string) (AssetInCache, error) {
+	b, err := os.ReadFile(metaPath)
+	if err != nil {
+		return AssetInCache{}, err
+	}
+	asset := AssetInCache{}
+	if err := json.Unmarshal(b, &asset); err != nil {
+		return AssetInCache{}, fmt.Errorf("%s: %w", metaPath, err)
+	}
+	asset.Path = strings.TrimSuffix(metaPath, ".json") + "-" + asset.URL.FileName().String()
+	return asset, nil
+}
+
+// writeAssetInCache write metadata of cached asset.
+func writeAssetInCache(metaPath string, asset AssetInCache) error {
+	b, err := json.MarshalIndent(asset, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(metaPath, b, 0644)
+}
+
+// cacheKey return key of s, e.g. URL, in cache directory.
+func cacheKey(s string) string {
+	key := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(key[:])
+}
+
+// remoteFile is response body of file opened by open.
+// resumed is true if body is contents after requested offset.
+// validator is ETag or Last-Modified of file which is used to resume download later.
+type remoteFile struct {
+	body      io.ReadCloser
+	size      int64
+	resumed   bool
+	validator string
+}
+
+// open send GET request to url and return response body.
+// If offset is positive, contents after offset are requested by Range header only if file still matches validator by If-Range header.
+// If server ignores them or respond contents from unexpected position, whole file is returned instead.
+// Request is aborted if server doesn't respond within downloadTimeout, or response body stalls longer than downloadIdleTimeout.
+// If server respond unexpected HTTP status, this return *httpStatusError.
+func (r *InfrastructureRepository) open(ctx context.Context, url URL, offset int64, validator string) (remoteFile, error) {
+	reqCtx, cancel := context.WithCancel(ctx)
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, url.String(), nil)
+	if err != nil {
+		cancel()
+		return remoteFile{}, err
+	}
+	r.authorize(req)
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+		req.Header.Set("If-Range", validator)
+	}
+	resp, err := r.client.Do(req)
+	if err != nil {
+		cancel()
+		return remoteFile{}, err
+	}
+
+	switch {
+	case resp.StatusCode == http.StatusOK:
+		return newRemoteFile(resp, false, cancel), nil
+	case resp.StatusCode == http.StatusPartialContent && offset > 0 && rangeStart(resp) == offset:
+		return newRemoteFile(resp, true, cancel), nil
+	}
+	resp.Body.Close()
+	cancel()
+
+	switch {
+	case resp.StatusCode == http.StatusPartialContent && offset > 0:
+		// server returned unexpected range. Download whole file again.
+		return r.open(ctx, url, 0, "")
+	case resp.StatusCode == http.StatusRequestedRangeNotSatisfiable && offset > 0:
+		// partial file is broken or file was changed. Download whole file again.
+		return r.open(ctx, url, 0, "")
+	case resp.StatusCode == http.StatusNotFound && r.token != "":
+		if repo, tag, name, err := ParseGitHubReleaseAssetURL(url, r.githubHost); err == nil {
+			return r.openGitHubReleaseAsset(ctx, repo, tag, name)
+		}
+	}
+	return remoteFile{}, newHTTPStatusError(url, resp)
+}
+
+// newRemoteFile return response body as remote file. cancel is called to abort request when body stalls or is closed.
+// Weak ETag can't be used by If-Range, so Last-Modified is used instead.
+func newRemoteFile(resp *http.Response, resumed bool, cancel context.CancelFunc) remoteFile {
+	validator := resp.Header.Get("ETag")
+	if validator == "" || strings.HasPrefix(validator, "W/") {
+		validator = resp.Header.Get("Last-Modified")
+	}
+	return remoteFile{
+		body:      newIdleTimeoutBody(resp.Body, downloadIdleTimeout, cancel),
+		size:      resp.ContentLength,
+		resumed:   resumed,
+		validator: validator,
+	}
+}
+
+// rangeStart return first byte position in Content-Range header of partial response, or -1 if it is not valid.
+func rangeStart(resp *http.Response) int64 {
+	var start, end int64
+	if _, err := fmt.Sscanf(resp.Header.Get("Content-Range"), "bytes %d-%d", &start, &end); err != nil {
+		return -1
+	}
+	return start
+}
+
+// authorize set token to request if it is sent to GitHub Enterprise Server, GitLab or Gitea,
+// because assets in them may require authentication.
+// Authorization headers are dropped by http.Client when redirected to another host.
+func (r *InfrastructureRepository) authorize(req *http.Request) {
+	if r.token != "" && r.githubHost != "" && req.URL.Host == r.githubHost {
+		req.Header.Set("Authorization", "token "+r.token)
+	}
+	// GitLab accepts token also by Authorization header, which is not leaked to other host on redirect unlike PRIVATE-TOKEN header.
+	if r.gitlabToken != "" && req.URL.Host == hostOf(r.gitlabURL) {
+		req.Header.Set("Authorization", "Bearer "+r.gitlabToken)
+	}
+	if r.giteaToken != "" && req.URL.Host == hostOf(r.giteaURL) {
+		req.Header.Set("Authorization", "token "+r.giteaToken)
+	}
+}
+
+// hostOf return host of rawURL, or empty string if it is not valid URL.
+func hostOf(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
+// openGitHubReleaseAsset download GitHub release asset through releases asset API and return it as remote file.
+// Asset is redirected to storage such as S3 and token is not sent to it.
+func (r *InfrastructureRepository) openGitHubReleaseAsset(ctx context.Context, repo GitHubRepository, tag string, name FileName) (remoteFile, error) {
+	release, _, err := r.github.Repositories.GetReleaseByTag(ctx, repo.Owner, repo.Name, tag)
+	if err != nil {
+		return remoteFile{}, err
+	}
+	for _, asset := range release.Assets {
+		if asset.GetName() != name.String() {
+			continue
+		}
+		reqCtx, cancel := context.WithCancel(ctx)
+		body, _, err := r.github.Repositories.DownloadReleaseAsset(reqCtx, repo.Owner, repo.Name, asset.GetID(), r.client)
+		if err != nil {
+			cancel()
+			return remoteFile{}, err
+		}
+		return remoteFile{
+			body: newIdleTimeoutBody(body, downloadIdleTimeout, cancel),
+			size: int64(asset.GetSize()),
+		}, nil
+	}
+	return remoteFile{}, fmt.Errorf("%w: %s in %s/%s %s", ErrAssetNotFound, name, repo.Owner, repo.Name, tag)
+}
+
+// ReadFile read file at path.
+func (r *InfrastructureRepository) ReadFile(path string) (File, error) {
+	body, err := os.ReadFile(path)
 	if err != nil {
 		return File{}, err
 	}
+	return NewFile(NewFileName(filepath.Base(path)), body), nil
+}
+
+// CreateTempFile create temporary file in dir.
+// It is renamed to be installed by RenameTempFile, or removed by RemoveTempFile.
+func (r *InfrastructureRepository) CreateTempFile(dir string) (*os.File, error) {
+	return os.CreateTemp(dir, ".go-get-release-*")
+}
+
+// RenameTempFile close temporary file, change its mode to perm and rename it to path.
+func (r *InfrastructureRepository) RenameTempFile(file *os.File, path string, perm fs.FileMode) error {
+	if err := file.Chmod(perm); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
 
-	return NewFile(url.FileName(), dst.Bytes()), nil
+// RemoveTempFile close and remove temporary file.
+// Errors are ignored because file may be already renamed.
+func (r *InfrastructureRepository) RemoveTempFile(file *os.File) {
+	_ = file.Close()
+	_ = os.Remove(file.Name())
+}
+
+// ExtraPath return path where extra file bundled with executable binary is installed.
+// It is under XDG data home, e.g. "~/.local/share/bash-completion/completions/<name>".
+func (r *InfrastructureRepository) ExtraPath(extra Extra, execBinary ExecBinary) (string, error) {
+	dir, err := dataHome()
+	if err != nil {
+		return "", err
+	}
+	path, err := extra.InstallPath(execBinary)
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, path), nil
+}
+
+// CreateTempFileFor create temporary file in directory of path, which is renamed to path by RenameTempFile.
+// Parent directories are created if they don't exist.
+func (r *InfrastructureRepository) CreateTempFileFor(path string) (*os.File, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, err
+	}
+	return r.CreateTempFile(filepath.Dir(path))
+}
+
+// writeFile write b to path atomically by renaming temporary file in same directory,
+// not to leave broken file when it is interrupted or read partially by concurrent process.
+// Parent directories are created if they don't exist.
+func (r *InfrastructureRepository) writeFile(path string, b []byte, perm fs.FileMode) error {
+	file, err := r.CreateTempFileFor(path)
+	if err != nil {
+		return err
+	}
+	defer r.RemoveTempFile(file)
+	if _, err := file.Write(b); err != nil {
+		return err
+	}
+	return r.RenameTempFile(file, path, perm)
 }
 
-// WriteFile write file to specified directory.
-func (r *InfrastructureRepository) WriteFile(file File, dir string, perm fs.FileMode) error {
-	path := filepath.Join(dir, file.Name.String())
-	return os.WriteFile(path, file.Body, perm)
+// RemoveFile remove file at path.
+func (r *InfrastructureRepository) RemoveFile(path string) error {
+	return os.Remove(path)
 }
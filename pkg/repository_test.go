@@ -2,16 +2,46 @@ package pkg
 
 import (
 	"context"
+	"fmt"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
 
 func NewInfrastructureRepositoryForTest(ctx context.Context, t *testing.T) *InfrastructureRepository {
 	t.Helper()
-	return NewInfrastructureRepository(ctx, os.Getenv("GITHUB_TOKEN"))
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	repository, err := NewInfrastructureRepository(ctx, InfrastructureOptions{Token: os.Getenv("GITHUB_TOKEN")})
+	require.NoError(t, err)
+	return repository
+}
+
+// NewTestDataServerForTest set XDG_DATA_HOME and XDG_CACHE_HOME to temporary directories
+// and return HTTP server which serves files in testdata directory.
+// If handler is not nil, requests are passed to it with file server, e.g. to count them or replace responses.
+func NewTestDataServerForTest(t *testing.T, handler func(w http.ResponseWriter, r *http.Request, fileServer http.Handler)) *httptest.Server {
+	t.Helper()
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	fileServer := http.FileServer(http.Dir("./testdata"))
+	if handler == nil {
+		handler = func(w http.ResponseWriter, r *http.Request, fileServer http.Handler) {
+			fileServer.ServeHTTP(w, r)
+		}
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler(w, r, fileServer)
+	}))
+	t.Cleanup(server.Close)
+	return server
 }
 
 func TestInfrastructureRepositorySearchGitHubRepository(t *testing.T) {
@@ -188,3 +218,578 @@ func TestInfrastructureRepositoryDownload(t *testing.T) {
 		})
 	}
 }
+
+func TestInfrastructureRepositoryLoadManifest(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		manifest Manifest
+	}{
+		{
+			name: "go-get-release.yaml",
+			path: "./testdata/go-get-release.yaml",
+			manifest: NewManifest([]PackageInManifest{
+				NewPackageInManifest("hashicorp/terraform=v1.5.0", "", "", ""),
+				NewPackageInManifest("cli/cli=v2.21.1", "linux", "arm64", "gh"),
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctx := context.Background()
+			repository := NewInfrastructureRepositoryForTest(ctx, t)
+			manifest, err := repository.LoadManifest(tt.path)
+			assert.NoError(err)
+			assert.Equal(tt.manifest, manifest)
+		})
+	}
+}
+
+func TestInfrastructureRepositoryWriteLock(t *testing.T) {
+	tests := []struct {
+		name string
+		lock Lock
+	}{
+		{
+			name: "hashicorp/terraform",
+			lock: NewLock([]PackageInLock{
+				NewPackageInLock("hashicorp", "terraform", "v1.5.0", "https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_linux_amd64.zip", "terraform", "8cd07f3a5ff98f2a78cfc366c13fb123eb8d29c1ca37c79df190425d5b9e424d"),
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			ctx := context.Background()
+			repository := NewInfrastructureRepositoryForTest(ctx, t)
+			path := filepath.Join(t.TempDir(), "go-get-release.lock")
+			err := repository.WriteLock(path, tt.lock)
+			assert.NoError(err)
+			lock, err := repository.LoadLock(path)
+			assert.NoError(err)
+			assert.Equal(tt.lock, lock)
+			// Lock file is written through temporary file, which must not be left.
+			entries, err := os.ReadDir(filepath.Dir(path))
+			assert.NoError(err)
+			assert.Len(entries, 1)
+		})
+	}
+}
+
+func TestInfrastructureRepositoryWriteInventory(t *testing.T) {
+	tests := []struct {
+		name      string
+		inventory Inventory
+	}{
+		{
+			name: "hashicorp/terraform",
+			inventory: NewInventory(map[string]PackageInInventory{
+				"/usr/local/bin/terraform": NewPackageInInventory("hashicorp", "terraform", "v1.5.0", "", "https://releases.hashicorp.com/terraform/1.5.0/terraform_1.5.0_linux_amd64.zip", "terraform", NewPlatform("linux", "amd64"), "/usr/local/bin/terraform", "8cd07f3a5ff98f2a78cfc366c13fb123eb8d29c1ca37c79df190425d5b9e424d", time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)),
+			}),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			t.Setenv("XDG_DATA_HOME", t.TempDir())
+			ctx := context.Background()
+			repository := NewInfrastructureRepositoryForTest(ctx, t)
+			inventory, err := repository.LoadInventory()
+			assert.NoError(err)
+			assert.Empty(inventory.Packages)
+			err = repository.WriteInventory(tt.inventory)
+			assert.NoError(err)
+			inventory, err = repository.LoadInventory()
+			assert.NoError(err)
+			assert.Equal(tt.inventory, inventory)
+			// Inventory is written through temporary file, which must not be left.
+			path, err := inventoryPath()
+			assert.NoError(err)
+			entries, err := os.ReadDir(filepath.Dir(path))
+			assert.NoError(err)
+			assert.Len(entries, 1)
+		})
+	}
+}
+
+func TestInfrastructureRepositoryLoadIndex(t *testing.T) {
+	tests := []struct {
+		name        string
+		configIndex string
+		envIndex    string
+		flagIndex   string
+		execBinary  ExecBinaryInIndex
+	}{
+		{
+			name:       "built-in",
+			execBinary: NewExecBinaryInIndex("argocd"),
+		},
+		{
+			name:        "config directory",
+			configIndex: "- owner: argoproj\n  repo: argo-cd\n  execBinary:\n    name: argocd-config\n",
+			execBinary:  NewExecBinaryInIndex("argocd-config"),
+		},
+		{
+			name:        "environment variable",
+			configIndex: "- owner: argoproj\n  repo: argo-cd\n  execBinary:\n    name: argocd-config\n",
+			envIndex:    "- owner: argoproj\n  repo: argo-cd\n  execBinary:\n    name: argocd-env\n",
+			execBinary:  NewExecBinaryInIndex("argocd-env"),
+		},
+		{
+			name:        "flag",
+			configIndex: "- owner: argoproj\n  repo: argo-cd\n  execBinary:\n    name: argocd-config\n",
+			envIndex:    "- owner: argoproj\n  repo: argo-cd\n  execBinary:\n    name: argocd-env\n",
+			flagIndex:   "- owner: argoproj\n  repo: argo-cd\n  execBinary:\n    name: argocd-flag\n",
+			execBinary:  NewExecBinaryInIndex("argocd-flag"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			configDir := t.TempDir()
+			t.Setenv("XDG_CONFIG_HOME", configDir)
+			t.Setenv("GO_GET_RELEASE_INDEX", "")
+			indexPaths := []string{}
+			if tt.configIndex != "" {
+				assert.NoError(os.MkdirAll(filepath.Join(configDir, "go-get-release", "index.d"), 0755))
+				assert.NoError(os.WriteFile(filepath.Join(configDir, "go-get-release", "index.d", "index.yaml"), []byte(tt.configIndex), 0644))
+			}
+			if tt.envIndex != "" {
+				path := filepath.Join(t.TempDir(), "index.yaml")
+				assert.NoError(os.WriteFile(path, []byte(tt.envIndex), 0644))
+				t.Setenv("GO_GET_RELEASE_INDEX", path)
+			}
+			if tt.flagIndex != "" {
+				path := filepath.Join(t.TempDir(), "index.yaml")
+				assert.NoError(os.WriteFile(path, []byte(tt.flagIndex), 0644))
+				indexPaths = append(indexPaths, path)
+			}
+
+			ctx := context.Background()
+			repository, err := NewInfrastructureRepository(ctx, InfrastructureOptions{Token: os.Getenv("GITHUB_TOKEN"), IndexPaths: indexPaths})
+			assert.NoError(err)
+			index, err := repository.LoadIndex()
+			assert.NoError(err)
+			execBinary, err := index.FindExecBinary(NewRepository("argoproj", "argo-cd"))
+			assert.NoError(err)
+			assert.Equal(tt.execBinary, execBinary)
+			assert.True(index.HasAsset(NewRepository("hashicorp", "terraform"), NewPlatform("linux", "amd64")))
+		})
+	}
+}
+
+func TestInfrastructureRepositoryLoadRemoteIndex(t *testing.T) {
+	assert := require.New(t)
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+
+	body := []byte("- owner: shibataka000\n  repo: internal-tool\n  execBinary:\n    name: tool\n")
+	requests := 0
+	notModified := 0
+	mode := ""
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch mode {
+		case "captive portal":
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write([]byte("<html><body>Sign in to continue</body></html>"))
+			return
+		case "black hole":
+			select {
+			case <-r.Context().Done():
+			case <-time.After(5 * time.Second):
+			}
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write(body)
+	}))
+	url := NewURL(server.URL + "/index.yaml")
+	expected := NewIndex([]RepositoryInIndex{
+		NewRepositoryInIndex("shibataka000", "internal-tool", nil, NewExecBinaryInIndex("tool")),
+	})
+
+	ctx := context.Background()
+	repository := NewInfrastructureRepositoryForTest(ctx, t)
+
+	// fetch index from server
+	index, err := repository.LoadRemoteIndex(url)
+	assert.NoError(err)
+	assert.Equal(expected, index)
+	assert.Equal(1, requests)
+	assert.Equal(0, notModified)
+
+	// index and its metadata are cached in one file not to be out of sync
+	dir, err := cacheDir()
+	assert.NoError(err)
+	entries, err := os.ReadDir(filepath.Join(dir, "index"))
+	assert.NoError(err)
+	assert.Len(entries, 1)
+
+	// revalidate cached index
+	index, err = repository.LoadRemoteIndex(url)
+	assert.NoError(err)
+	assert.Equal(expected, index)
+	assert.Equal(2, requests)
+	assert.Equal(1, notModified)
+
+	// fallback to cached index when response is not index
+	mode = "captive portal"
+	index, err = repository.LoadRemoteIndex(url)
+	assert.NoError(err)
+	assert.Equal(expected, index)
+
+	// fallback to cached index when server doesn't respond
+	mode = "black hole"
+	timeout := remoteIndexTimeout
+	remoteIndexTimeout = 100 * time.Millisecond
+	defer func() { remoteIndexTimeout = timeout }()
+	index, err = repository.LoadRemoteIndex(url)
+	assert.NoError(err)
+	assert.Equal(expected, index)
+
+	// fallback to cached index when server is down
+	server.Close()
+	index, err = repository.LoadRemoteIndex(url)
+	assert.NoError(err)
+	assert.Equal(expected, index)
+
+	// no cache
+	_, err = repository.LoadRemoteIndex(NewURL(server.URL + "/other.yaml"))
+	assert.Error(err)
+}
+
+func TestInfrastructureRepositoryGitHubEnterprise(t *testing.T) {
+	assert := require.New(t)
+	token := "test-token"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/repos/shibataka000/internal-tool":
+			_, _ = w.Write([]byte(`{"name":"internal-tool","owner":{"login":"shibataka000"}}`))
+		case "/shibataka000/internal-tool/releases/download/v1.0.0/internal-tool":
+			if r.Header.Get("Authorization") != "token "+token {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte("helloworld\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	ctx := context.Background()
+	repository, err := NewInfrastructureRepository(ctx, InfrastructureOptions{Token: token, GitHubURL: server.URL})
+	assert.NoError(err)
+
+	repo, err := repository.FindGitHubRepository(ctx, "shibataka000", "internal-tool")
+	assert.NoError(err)
+	assert.Equal(NewGitHubRepository("shibataka000", "internal-tool"), repo)
+
+	file, err := repository.Download(NewURL(server.URL+"/shibataka000/internal-tool/releases/download/v1.0.0/internal-tool"), io.Discard)
+	assert.NoError(err)
+	assert.Equal(NewFile("internal-tool", []byte("helloworld\n")), file)
+}
+
+func TestGitHubSource(t *testing.T) {
+	assert := require.New(t)
+	requests := map[string]int{}
+	mu := sync.Mutex{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests[r.URL.Path]++
+		mu.Unlock()
+		switch r.URL.Path {
+		case "/api/v3/repos/shibataka000/internal-tool/releases/tags/v1.0.0":
+			_, _ = w.Write([]byte(`{"id":1,"tag_name":"v1.0.0"}`))
+		case "/api/v3/repos/shibataka000/internal-tool/releases/1/assets":
+			_, _ = w.Write([]byte(`[{"browser_download_url":"https://example.com/internal-tool_linux_amd64"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	ctx := context.Background()
+	repository, err := NewInfrastructureRepository(ctx, InfrastructureOptions{GitHubURL: server.URL})
+	assert.NoError(err)
+	source := newGitHubSource(repository)
+	repo := NewRepository("shibataka000", "internal-tool")
+
+	release, err := source.FindReleaseByTag(ctx, repo, "v1.0.0")
+	assert.NoError(err)
+	assert.Equal(NewRelease("v1.0.0"), release)
+
+	assets, err := source.ListAssets(ctx, repo, release)
+	assert.NoError(err)
+	assert.Equal([]Asset{NewAsset("https://example.com/internal-tool_linux_amd64")}, assets)
+	// Release found by tag is not looked up again to list its assets.
+	assert.Equal(1, requests["/api/v3/repos/shibataka000/internal-tool/releases/tags/v1.0.0"])
+
+	_, err = source.FindReleaseByTag(ctx, repo, "v9.9.9")
+	assert.ErrorIs(err, ErrReleaseNotFound)
+
+	_, err = source.LatestRelease(ctx, repo)
+	assert.ErrorIs(err, ErrReleaseNotFound)
+}
+
+func TestInfrastructureRepositoryDownloadPrivateGitHubAsset(t *testing.T) {
+	assert := require.New(t)
+	token := "test-token"
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/repos/shibataka000/private-tool/releases/tags/v1.0.0":
+			if r.Header.Get("Authorization") != "Bearer "+token {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_, _ = w.Write([]byte(`{"id":1,"tag_name":"v1.0.0","assets":[{"id":10,"name":"private-tool","size":11}]}`))
+		case "/api/v3/repos/shibataka000/private-tool/releases/assets/10":
+			if r.Header.Get("Authorization") != "Bearer "+token || r.Header.Get("Accept") != "application/octet-stream" {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			http.Redirect(w, r, server.URL+"/storage/private-tool", http.StatusFound)
+		case "/storage/private-tool":
+			if r.Header.Get("Authorization") != "" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			_, _ = w.Write([]byte("helloworld\n"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv("XDG_CACHE_HOME", t.TempDir())
+	ctx := context.Background()
+	repository, err := NewInfrastructureRepository(ctx, InfrastructureOptions{Token: token, GitHubURL: server.URL})
+	assert.NoError(err)
+
+	file, err := repository.Download(NewURL(server.URL+"/shibataka000/private-tool/releases/download/v1.0.0/private-tool"), io.Discard)
+	assert.NoError(err)
+	assert.Equal(NewFile("private-tool", []byte("helloworld\n")), file)
+}
+
+func TestInfrastructureRepositoryDownloadAlwaysFetch(t *testing.T) {
+	tests := []struct {
+		name     string
+		offline  bool
+		requests int
+	}{
+		{
+			name:     "online",
+			requests: 2,
+		},
+		{
+			name:     "offline",
+			offline:  true,
+			requests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			requests := 0
+			server := NewTestDataServerForTest(t, func(w http.ResponseWriter, r *http.Request, fileServer http.Handler) {
+				requests++
+				fileServer.ServeHTTP(w, r)
+			})
+			url := NewURL(server.URL + "/test.gz")
+			ctx := context.Background()
+
+			// file downloaded by Download is cached only to be used in offline mode.
+			online, err := NewInfrastructureRepository(ctx, InfrastructureOptions{})
+			assert.NoError(err)
+			_, err = online.Download(url, io.Discard)
+			assert.NoError(err)
+
+			repository, err := NewInfrastructureRepository(ctx, InfrastructureOptions{Offline: tt.offline})
+			assert.NoError(err)
+			file, err := repository.Download(url, io.Discard)
+			assert.NoError(err)
+			assert.Equal("test.gz", file.Name.String())
+			assert.Equal(tt.requests, requests)
+		})
+	}
+}
+
+func TestInfrastructureRepositoryDownloadToCache(t *testing.T) {
+	expected, err := os.ReadFile("./testdata/test.gz")
+	require.NoError(t, err)
+	digest := NewFile("test.gz", expected).SHA256()
+
+	tests := []struct {
+		name     string
+		digest   Digest
+		corrupt  bool
+		requests int
+	}{
+		{
+			name:     "revalidate cache",
+			requests: 2,
+		},
+		{
+			name:     "corrupted cache",
+			corrupt:  true,
+			requests: 2,
+		},
+		{
+			name:     "expected digest",
+			digest:   digest,
+			requests: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			requests := 0
+			server := NewTestDataServerForTest(t, func(w http.ResponseWriter, r *http.Request, fileServer http.Handler) {
+				requests++
+				fileServer.ServeHTTP(w, r)
+			})
+			url := NewURL(server.URL + "/test.gz")
+			ctx := context.Background()
+			repository := NewInfrastructureRepositoryForTest(ctx, t)
+
+			for i := 0; i < 2; i++ {
+				file, err := repository.DownloadToCache(url, tt.digest, io.Discard)
+				assert.NoError(err)
+				body, err := io.ReadAll(file)
+				assert.NoError(err)
+				assert.NoError(file.Close())
+				assert.Equal(expected, body)
+				if tt.corrupt && i == 0 {
+					assets, err := repository.ListCachedAssets()
+					assert.NoError(err)
+					assert.NoError(os.WriteFile(assets[0].Path, []byte("corrupted"), 0644))
+				}
+			}
+			assert.Equal(tt.requests, requests)
+
+			assets, err := repository.ListCachedAssets()
+			assert.NoError(err)
+			assert.Len(assets, 1)
+			assert.Equal(url, assets[0].URL)
+			assert.Equal(int64(len(expected)), assets[0].Size)
+			assert.Equal(digest, assets[0].SHA256)
+			assert.Equal(tt.digest, assets[0].ExpectedDigest)
+
+			// skip corrupted metadata
+			assert.NoError(os.WriteFile(filepath.Join(filepath.Dir(assets[0].Path), "corrupted.json"), []byte("{"), 0644))
+			listed, err := repository.ListCachedAssets()
+			assert.ErrorContains(err, "corrupted.json")
+			assert.Equal(assets, listed)
+
+			// remove or evict cache
+			if tt.digest == "" {
+				assert.NoError(repository.RemoveCachedAsset(assets[0]))
+			} else {
+				assert.NoError(repository.EvictCachedAsset(url, tt.digest))
+			}
+			assert.NoFileExists(assets[0].Path)
+			assert.NoError(repository.EvictCachedAsset(url, tt.digest))
+		})
+	}
+}
+
+func TestInfrastructureRepositoryDownloadToCacheRevalidate(t *testing.T) {
+	tests := []struct {
+		name       string
+		etag       []string
+		offline    bool
+		unwritable bool
+		requests   int
+		body       string
+	}{
+		{
+			name:     "not modified",
+			etag:     []string{`"v1"`, `"v1"`},
+			requests: 2,
+			body:     "v1",
+		},
+		{
+			name:     "modified",
+			etag:     []string{`"v1"`, `"v2"`},
+			requests: 3,
+			body:     "v2",
+		},
+		{
+			name:     "no validator",
+			etag:     []string{"", ""},
+			requests: 2,
+			body:     "v2",
+		},
+		{
+			name:     "offline",
+			etag:     []string{`"v1"`, `"v2"`},
+			offline:  true,
+			requests: 1,
+			body:     "v1",
+		},
+		{
+			name:       "unwritable cache",
+			etag:       []string{`"v1"`, `"v2"`},
+			unwritable: true,
+			requests:   2,
+			body:       "v2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			version := 0
+			requests := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if tt.etag[version] != "" {
+					w.Header().Set("ETag", tt.etag[version])
+				}
+				http.ServeContent(w, r, "test", time.Time{}, strings.NewReader(fmt.Sprintf("v%d", version+1)))
+			}))
+			defer server.Close()
+			url := NewURL(server.URL + "/test")
+
+			ctx := context.Background()
+			repository := NewInfrastructureRepositoryForTest(ctx, t)
+			if tt.unwritable {
+				// cache directory can't be created under regular file.
+				file := filepath.Join(t.TempDir(), "file")
+				assert.NoError(os.WriteFile(file, []byte{}, 0644))
+				t.Setenv("XDG_CACHE_HOME", file)
+			}
+			file, err := repository.DownloadToCache(url, "", io.Discard)
+			assert.NoError(err)
+			assert.NoError(file.Close())
+
+			version = 1
+			if tt.offline {
+				repository, err = NewInfrastructureRepository(ctx, InfrastructureOptions{Offline: true})
+				assert.NoError(err)
+			}
+			file, err = repository.DownloadToCache(url, "", io.Discard)
+			assert.NoError(err)
+			defer file.Close()
+			body, err := io.ReadAll(file)
+			assert.NoError(err)
+			assert.Equal(tt.body, string(body))
+			assert.Equal(tt.requests, requests)
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package pkg
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// Source is name of host which publishes releases.
+type Source string
+
+const (
+	// SourceGitHub is GitHub or GitHub Enterprise Server.
+	SourceGitHub Source = "github"
+	// SourceGitLab is GitLab.
+	SourceGitLab Source = "gitlab"
+	// SourceCodeberg is codeberg.org.
+	SourceCodeberg Source = "codeberg"
+	// SourceGitea is self-hosted Gitea or Forgejo.
+	SourceGitea Source = "gitea"
+	// SourceURL means asset is downloaded from URL directly without any release host.
+	SourceURL Source = "url"
+)
+
+// ReleaseSource find repositories, releases and assets in host which publishes releases.
+// Release is identified by its tag in every host.
+type ReleaseSource interface {
+	SearchRepository(ctx context.Context, query string) (Repository, error)
+	FindRepository(ctx context.Context, owner string, name string) (Repository, error)
+	LatestRelease(ctx context.Context, repo Repository) (Release, error)
+	ListReleases(ctx context.Context, repo Repository) ([]Release, error)
+	FindReleaseByTag(ctx context.Context, repo Repository, tag string) (Release, error)
+	ListAssets(ctx context.Context, repo Repository, release Release) ([]Asset, error)
+}
+
+// gitHubSource is release source backed by GitHub.
+// It shares GitHub client with infrastructure repository, which also uses it to download assets in private repositories.
+// GitHub lists assets by release ID, so IDs of releases found by this are kept not to look up them again by tag.
+type gitHubSource struct {
+	repository *InfrastructureRepository
+	releaseIDs *sync.Map
+}
+
+// cachedSource is release source which caches metadata fetched from underlying release source.
+// In offline mode, metadata is read only from cache.
+type cachedSource struct {
+	repository *InfrastructureRepository
+	source     ReleaseSource
+	host       string
+}
+
+// NewSource return new source instance.
+// Empty source means GitHub.
+func NewSource(source string) (Source, error) {
+	switch Source(source) {
+	case "", SourceGitHub, SourceGitLab, SourceCodeberg, SourceGitea:
+		return Source(source), nil
+	default:
+		return "", fmt.Errorf("%s is unknown source", source)
+	}
+}
+
+// String return string typed source.
+func (s Source) String() string {
+	return string(s)
+}
+
+// IsGitHub return true if source is GitHub.
+func (s Source) IsGitHub() bool {
+	return s == "" || s == SourceGitHub
+}
+
+// ReleaseSource return release source specified by source.
+// Metadata fetched from returned release source is cached locally to be used in offline mode.
+// Codeberg is accessed with Gitea token only if Gitea URL is Codeberg, not to send token for self-hosted Gitea to it.
+func (r *InfrastructureRepository) ReleaseSource(source Source) (ReleaseSource, error) {
+	switch {
+	case source.IsGitHub():
+		host := r.githubHost
+		if host == "" {
+			host = "github.com"
+		}
+		return newCachedSource(r, newGitHubSource(r), host), nil
+	case source == SourceGitLab:
+		return newCachedSource(r, newGitLabSource(r.gitlabURL, r.gitlabToken), r.gitlabURL), nil
+	case source == SourceCodeberg:
+		return newCachedSource(r, newGiteaSource(defaultCodebergURL, r.codebergToken()), defaultCodebergURL), nil
+	case source == SourceGitea:
+		return newCachedSource(r, newGiteaSource(r.giteaURL, r.giteaToken), r.giteaURL), nil
+	default:
+		return nil, fmt.Errorf("%s is unknown source", source)
+	}
+}
+
+// codebergToken return token to access Codeberg.
+func (r *InfrastructureRepository) codebergToken() string {
+	if hostOf(r.giteaURL) != hostOf(defaultCodebergURL) {
+		return ""
+	}
+	return r.giteaToken
+}
+
+// newGitHubSource return new release source backed by GitHub.
+func newGitHubSource(repository *InfrastructureRepository) gitHubSource {
+	return gitHubSource{
+		repository: repository,
+		releaseIDs: &sync.Map{},
+	}
+}
+
+// SearchRepository search GitHub repository.
+func (s gitHubSource) SearchRepository(ctx context.Context, query string) (Repository, error) {
+	repo, err := s.repository.SearchGitHubRepository(ctx, query)
+	return Repository(repo), err
+}
+
+// FindRepository find GitHub repository.
+func (s gitHubSource) FindRepository(ctx context.Context, owner string, name string) (Repository, error) {
+	repo, err := s.repository.FindGitHubRepository(ctx, owner, name)
+	return Repository(repo), err
+}
+
+// LatestRelease return latest GitHub release.
+func (s gitHubSource) LatestRelease(ctx context.Context, repo Repository) (Release, error) {
+	release, err := s.repository.LatestGitHubRelease(ctx, GitHubRepository(repo))
+	return s.release(repo, release), err
+}
+
+// ListReleases list published releases in GitHub repository.
+func (s gitHubSource) ListReleases(ctx context.Context, repo Repository) ([]Release, error) {
+	releases, err := s.repository.ListGitHubReleases(ctx, GitHubRepository(repo))
+	result := []Release{}
+	for _, release := range releases {
+		result = append(result, s.release(repo, release))
+	}
+	return result, err
+}
+
+// FindReleaseByTag return GitHub release by tag.
+func (s gitHubSource) FindReleaseByTag(ctx context.Context, repo Repository, tag string) (Release, error) {
+	release, err := s.repository.FindGitHubReleaseByTag(ctx, GitHubRepository(repo), tag)
+	return s.release(repo, release), err
+}
+
+// ListAssets list assets in GitHub release.
+// GitHub lists assets by release ID, so release is looked up by its tag first unless it was already found by this.
+func (s gitHubSource) ListAssets(ctx context.Context, repo Repository, release Release) ([]Asset, error) {
+	found := NewGitHubRelease(0, release.Tag)
+	if id, ok := s.releaseIDs.Load(s.releaseKey(repo, release.Tag)); ok {
+		found.ID = id.(int64)
+	} else {
+		var err error
+		found, err = s.repository.FindGitHubReleaseByTag(ctx, GitHubRepository(repo), release.Tag)
+		if err != nil {
+			return nil, err
+		}
+	}
+	assets, err := s.repository.ListGitHubAssets(ctx, GitHubRepository(repo), found)
+	if err != nil {
+		return nil, err
+	}
+	result := []Asset{}
+	for _, asset := range assets {
+		result = append(result, Asset(asset))
+	}
+	return result, nil
+}
+
+// release return GitHub release as release and keep its ID.
+func (s gitHubSource) release(repo Repository, release GitHubRelease) Release {
+	if release.ID != 0 {
+		s.releaseIDs.Store(s.releaseKey(repo, release.Tag), release.ID)
+	}
+	return NewRelease(release.Tag)
+}
+
+// releaseKey return key of release ID.
+func (s gitHubSource) releaseKey(repo Repository, tag string) string {
+	return fmt.Sprintf("%s/%s %s", repo.Owner, repo.Name, tag)
+}
+
+// newCachedSource return new release source which caches metadata fetched from source.
+// host identifies source in cache.
+func newCachedSource(repository *InfrastructureRepository, source ReleaseSource, host string) cachedSource {
+	return cachedSource{
+		repository: repository,
+		source:     source,
+		host:       host,
+	}
+}
+
+// SearchRepository search repository.
+func (s cachedSource) SearchRepository(ctx context.Context, query string) (Repository, error) {
+	return cachedMetadata(s, fmt.Sprintf("repository searched by %s", query), func() (Repository, error) {
+		return s.source.SearchRepository(ctx, query)
+	})
+}
+
+// FindRepository find repository.
+func (s cachedSource) FindRepository(ctx context.Context, owner string, name string) (Repository, error) {
+	return cachedMetadata(s, fmt.Sprintf("repository %s/%s", owner, name), func() (Repository, error) {
+		return s.source.FindRepository(ctx, owner, name)
+	})
+}
+
+// LatestRelease return latest release.
+func (s cachedSource) LatestRelease(ctx context.Context, repo Repository) (Release, error) {
+	return cachedMetadata(s, fmt.Sprintf("latest release of %s/%s", repo.Owner, repo.Name), func() (Release, error) {
+		return s.source.LatestRelease(ctx, repo)
+	})
+}
+
+// ListReleases list releases in repository.
+func (s cachedSource) ListReleases(ctx context.Context, repo Repository) ([]Release, error) {
+	return cachedMetadata(s, fmt.Sprintf("releases of %s/%s", repo.Owner, repo.Name), func() ([]Release, error) {
+		return s.source.ListReleases(ctx, repo)
+	})
+}
+
+// FindReleaseByTag return release by tag.
+func (s cachedSource) FindReleaseByTag(ctx context.Context, repo Repository, tag string) (Release, error) {
+	return cachedMetadata(s, fmt.Sprintf("release %s of %s/%s", tag, repo.Owner, repo.Name), func() (Release, error) {
+		return s.source.FindReleaseByTag(ctx, repo, tag)
+	})
+}
+
+// ListAssets list assets in release.
+func (s cachedSource) ListAssets(ctx context.Context, repo Repository, release Release) ([]Asset, error) {
+	return cachedMetadata(s, fmt.Sprintf("assets of %s/%s %s", repo.Owner, repo.Name, release.Tag), func() ([]Asset, error) {
+		return s.source.ListAssets(ctx, repo, release)
+	})
+}
+
+// cachedMetadata return metadata described by key.
+// Metadata is fetched by fetch and cached, or is read from cache in offline mode.
+func cachedMetadata[T any](s cachedSource, key string, fetch func() (T, error)) (T, error) {
+	var metadata T
+	dir, err := cacheDir()
+	if err != nil {
+		return metadata, err
+	}
+	path := filepath.Join(dir, "metadata", cacheKey(s.host+" "+key)+".json")
+
+	if s.repository.offline {
+		b, err := os.ReadFile(path)
+		if errors.Is(err, fs.ErrNotExist) {
+			return metadata, fmt.Errorf("%w: %s in %s", ErrNotCached, key, s.host)
+		}
+		if err != nil {
+			return metadata, err
+		}
+		if err := json.Unmarshal(b, &metadata); err != nil {
+			return metadata, fmt.Errorf("%s: %w", path, err)
+		}
+		return metadata, nil
+	}
+
+	metadata, err = fetch()
+	if err != nil {
+		return metadata, err
+	}
+	// Cache is written atomically not to be read partially by concurrent process.
+	// Failure to write it, e.g. because cache directory is read-only, doesn't fail fetching metadata.
+	if b, err := json.Marshal(metadata); err == nil {
+		_ = s.repository.writeFile(path, b, 0644)
+	}
+	return metadata, nil
+}
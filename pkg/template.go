@@ -0,0 +1,63 @@
+package pkg
+
+import (
+	"bytes"
+	"text/template"
+)
+
+// templateParam is parameter to render templates in index, such as URL templates and executable binary patterns.
+// Each of values is passed through quote if it is set, e.g. to escape them in regular expression.
+type templateParam struct {
+	Tag     string
+	OS      string
+	Arch    string
+	release Release
+	quote   func(string) string
+}
+
+// newTemplateParam return new template parameter instance to render template with release and platform.
+func newTemplateParam(release Release, platform Platform) templateParam {
+	return templateParam{
+		Tag:     release.Tag,
+		OS:      platform.OS,
+		Arch:    platform.Arch,
+		release: release,
+	}
+}
+
+// quoted return copy of template parameter whose values are passed through quote.
+func (p templateParam) quoted(quote func(string) string) templateParam {
+	return templateParam{
+		Tag:     quote(p.Tag),
+		OS:      quote(p.OS),
+		Arch:    quote(p.Arch),
+		release: p.release,
+		quote:   quote,
+	}
+}
+
+// SemVer return semver formatted release tag.
+// It is evaluated only if template uses it, so only such template fails to be rendered if release tag is not semver.
+func (p templateParam) SemVer() (string, error) {
+	semver, err := p.release.SemVer()
+	if err != nil {
+		return "", err
+	}
+	if p.quote != nil {
+		return p.quote(semver), nil
+	}
+	return semver, nil
+}
+
+// renderTemplate render text/template with param.
+func renderTemplate(tmpl string, param templateParam) (string, error) {
+	t, err := template.New("").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+	buf := new(bytes.Buffer)
+	if err := t.Execute(buf, param); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
@@ -0,0 +1,38 @@
+package pkg
+
+import (
+	"golang.org/x/mod/semver"
+)
+
+// Upgrade is pair of installed package and package which is available to replace it.
+type Upgrade struct {
+	Installed PackageInInventory
+	Available Package
+}
+
+// NewUpgrade return new upgrade instance.
+func NewUpgrade(installed PackageInInventory, available Package) Upgrade {
+	return Upgrade{
+		Installed: installed,
+		Available: available,
+	}
+}
+
+// IsOutdated return true if available release is newer than installed one.
+// If either tag is not valid semver, installed package is outdated when tags differ.
+func (u Upgrade) IsOutdated() bool {
+	installed, err := NewRelease(u.Installed.Tag).SemVer()
+	if err != nil {
+		return u.Installed.Tag != u.Available.Release.Tag
+	}
+	available, err := u.Available.Release.SemVer()
+	if err != nil {
+		return u.Installed.Tag != u.Available.Release.Tag
+	}
+	return semver.Compare("v"+available, "v"+installed) > 0
+}
+
+// InstallDir return directory where installed package is.
+func (u Upgrade) InstallDir() string {
+	return u.Installed.Dir()
+}
@@ -0,0 +1,53 @@
+package pkg
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeIsOutdated(t *testing.T) {
+	installedAt := time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		installed string
+		available string
+		outdated  bool
+	}{
+		{
+			name:      "v1.4.0 -> v1.5.0",
+			installed: "v1.4.0",
+			available: "v1.5.0",
+			outdated:  true,
+		},
+		{
+			name:      "v1.5.0 -> v1.5.0",
+			installed: "v1.5.0",
+			available: "v1.5.0",
+			outdated:  false,
+		},
+		{
+			name:      "v1.5.0 -> v1.4.0",
+			installed: "v1.5.0",
+			available: "v1.4.0",
+			outdated:  false,
+		},
+		{
+			name:      "nightly-1 -> nightly-2",
+			installed: "nightly-1",
+			available: "nightly-2",
+			outdated:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			installed := NewPackageInInventory("hashicorp", "terraform", tt.installed, "", "", "terraform", NewPlatform("linux", "amd64"), "/usr/local/bin/terraform", "", installedAt)
+			available := New(NewRepository("hashicorp", "terraform"), NewRelease(tt.available), NewAsset(""), NewExecBinary("terraform"), Checksum{})
+			assert.Equal(tt.outdated, NewUpgrade(installed, available).IsOutdated())
+		})
+	}
+}
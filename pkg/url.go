@@ -1,9 +1,8 @@
 package pkg
 
 import (
-	"bytes"
 	"path"
-	"text/template"
+	"strings"
 )
 
 // URL.
@@ -27,6 +26,11 @@ func (url URL) String() string {
 	return string(url)
 }
 
+// IsHTTP return true if URL scheme is http or https.
+func (url URL) IsHTTP() bool {
+	return strings.HasPrefix(url.String(), "http://") || strings.HasPrefix(url.String(), "https://")
+}
+
 // FileName return file name of downloaded file from this URL.
 func (url URL) FileName() FileName {
 	base := path.Base(url.String())
@@ -39,27 +43,11 @@ func (url URLTemplate) String() string {
 }
 
 // RenderWithRelease render URL with release.
+// Release tag must be semver only if template uses {{.SemVer}}.
 func (url URLTemplate) RenderWithRelease(release Release) (URL, error) {
-	semver, err := release.SemVer()
-	if err != nil {
-		return "", err
-	}
-	param := struct {
-		Tag    string
-		SemVer string
-	}{
-		Tag:    release.Tag,
-		SemVer: semver,
-	}
-
-	tmpl, err := template.New("").Parse(url.String())
-	if err != nil {
-		return "", err
-	}
-	buf := new(bytes.Buffer)
-	err = tmpl.Execute(buf, param)
+	rendered, err := renderTemplate(url.String(), newTemplateParam(release, Platform{}))
 	if err != nil {
 		return "", err
 	}
-	return NewURL(buf.String()), nil
+	return NewURL(rendered), nil
 }
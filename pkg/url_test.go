@@ -33,6 +33,7 @@ func TestURLTemplateRenderWithRelease(t *testing.T) {
 		tmpl    URLTemplate
 		release Release
 		url     URL
+		err     bool
 	}{
 		{
 			name:    "https://github.com/viaduct-ai/kustomize-sops/releases/download/{{.Tag}}/ksops_{{.SemVer}}_Linux_x86_64.tar.gz",
@@ -40,14 +41,61 @@ func TestURLTemplateRenderWithRelease(t *testing.T) {
 			release: NewRelease("v4.1.0"),
 			url:     NewURL("https://github.com/viaduct-ai/kustomize-sops/releases/download/v4.1.0/ksops_4.1.0_Linux_x86_64.tar.gz"),
 		},
+		{
+			name:    "tag is not semver",
+			tmpl:    NewURLTemplate("https://example.com/{{.Tag}}/tool.tar.gz"),
+			release: NewRelease("nightly"),
+			url:     NewURL("https://example.com/nightly/tool.tar.gz"),
+		},
+		{
+			name:    "semver is not available",
+			tmpl:    NewURLTemplate("https://example.com/{{.Tag}}/tool_{{.SemVer}}.tar.gz"),
+			release: NewRelease("nightly"),
+			err:     true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			assert := require.New(t)
 			url, err := tt.tmpl.RenderWithRelease(tt.release)
+			if tt.err {
+				assert.Error(err)
+				return
+			}
 			assert.NoError(err)
 			assert.Equal(tt.url, url)
 		})
 	}
 }
+
+func TestURLIsHTTP(t *testing.T) {
+	tests := []struct {
+		name   string
+		url    URL
+		isHTTP bool
+	}{
+		{
+			name:   "https",
+			url:    "https://example.com/index.yaml",
+			isHTTP: true,
+		},
+		{
+			name:   "http",
+			url:    "http://example.com/index.yaml",
+			isHTTP: true,
+		},
+		{
+			name:   "file path",
+			url:    "./index.yaml",
+			isHTTP: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert := require.New(t)
+			assert.Equal(tt.isHTTP, tt.url.IsHTTP())
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package pkg
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// appName is directory name used in XDG base directories.
+const appName = "go-get-release"
+
+// dataHome return base directory to store user data.
+// This is "$XDG_DATA_HOME" or "~/.local/share" by default.
+func dataHome() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return dir, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share"), nil
+}
+
+// dataDir return directory to store user data of this application.
+// This is "$XDG_DATA_HOME/go-get-release" or "~/.local/share/go-get-release" by default.
+func dataDir() (string, error) {
+	dir, err := dataHome()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, appName), nil
+}
+
+// configDir return directory to store user configuration of this application.
+// This is "$XDG_CONFIG_HOME/go-get-release" or "~/.config/go-get-release" by default.
+func configDir() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, appName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", appName), nil
+}
+
+// cacheDir return directory to store cache of this application.
+// This is "$XDG_CACHE_HOME/go-get-release" or "~/.cache/go-get-release" by default.
+func cacheDir() (string, error) {
+	if dir := os.Getenv("XDG_CACHE_HOME"); dir != "" {
+		return filepath.Join(dir, appName), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".cache", appName), nil
+}